@@ -1,55 +1,124 @@
 package main
 
 import (
-	"math/rand"
 	"snake/ui"
 	"time"
 )
 
 type apples []apple
 
-func (a apples) Update(board *gameBoard, _ time.Duration) {
+func (a apples) Update(board *gameBoard, delta time.Duration) {
 	for i := range a {
+		a[i].age(board, delta)
 		a[i].Update(board)
 	}
 }
 
+// visible reports whether the apple is on the board, rather than waiting for room to be
+// placed.
+func (a *apple) visible() bool {
+	return !a.Hidden
+}
+
 func (a apples) ForEach(f func(*apple)) {
 	for i := range a {
 		f(&a[i])
 	}
 }
 
+func (a apples) reset(board *gameBoard) {
+	// take the old apples off first, so they don't decide where the new ones go
+	for i := range a {
+		a[i].Hidden = true
+	}
+	for i := range a {
+		a[i] = newApple(board)
+	}
+}
+
 func newApples(b *gameBoard, cnt int) apples {
-	ret := make([]apple, 0, cnt)
+	// each apple goes on the board as soon as it's placed, so the next can't land on it
+	prev := b.apples
+	defer func() { b.apples = prev }()
+	b.apples = make(apples, 0, cnt)
 	for range cnt {
-		ret = append(ret, newApple(b))
+		b.apples = append(b.apples, newApple(b))
 	}
-	return ret
+	return b.apples
 }
 
+const (
+	goldenApplePoints = 5 * pointsPerApple
+	timedApplePoints  = 3 * pointsPerApple
+	// timedAppleLifetime is how long a timed apple stays on the board before it's replaced.
+	timedAppleLifetime = 8 * time.Second
+)
+
 type apple struct {
 	ui.AppleRenderer
 	eaten bool
+	// ttl is how long a timed apple has left on the board.
+	ttl time.Duration
 }
 
 func (a *apple) Update(board *gameBoard) {
-	if a.eaten {
-		a.setPos(board)
-		a.eaten = false
+	if a.eaten || a.Hidden {
+		a.respawn(board)
+	}
+}
+
+// age counts down the lifetime of a timed apple, replacing it once it expires.
+func (a *apple) age(board *gameBoard, delta time.Duration) {
+	if a.Kind != ui.TimedApple {
+		return
+	}
+	if a.ttl -= delta; a.ttl <= 0 {
+		a.respawn(board)
+	}
+}
+
+// respawn replaces the apple with a new one of a random kind somewhere else on the board.
+func (a *apple) respawn(b *gameBoard) {
+	o := b.occupied()
+	a.setPos(b)
+	a.eaten = false
+	a.Kind = b.randomAppleKind()
+	a.ttl = 0
+	if a.Kind == ui.TimedApple {
+		a.ttl = timedAppleLifetime
+	}
+	o.appleMoved(a)
+}
+
+// points returns the score for eating the apple.
+func (a *apple) points() uint {
+	switch a.Kind {
+	case ui.GoldenApple:
+		return goldenApplePoints
+	case ui.TimedApple:
+		return timedApplePoints
+	case ui.PoisonApple:
+		return 0
+	default:
+		return pointsPerApple
 	}
 }
 
+// setPos moves the apple to a random free cell allowed by the configured placement, hiding
+// it when there are none left.
 func (a *apple) setPos(b *gameBoard) {
-	p := ui.Position{X: rand.Intn(b.Right()), Y: rand.Intn(b.Bottom())}
-	for a.Pos == p || !b.IsInside(p) {
-		p = ui.Position{X: rand.Intn(b.Right()), Y: rand.Intn(b.Bottom())}
+	free := b.freeCells()
+	if len(free) == 0 {
+		a.Hidden = true
+		return
 	}
-	a.Pos = p
+	cells := b.cfg.ApplePlacement().candidates(b, free)
+	a.Pos = cells[b.rng.Intn(len(cells))]
+	a.Hidden = false
 }
 
 func newApple(b *gameBoard) apple {
 	var ret apple
-	ret.setPos(b)
+	ret.respawn(b)
 	return ret
 }
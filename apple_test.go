@@ -1,17 +1,33 @@
 package main
 
 import (
+	"math/rand"
 	"snake/ui"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 var testBoard = &gameBoard{
 	GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, 20, 20),
+	rng:               rand.New(rand.NewSource(1)),
+	cfg:               normalApplesConfig(),
+}
+
+// normalApplesConfig returns a configuration where only normal apples are spawned.
+func normalApplesConfig() *Config {
+	return &Config{appleWeights: map[ui.AppleKind]int{
+		ui.GoldenApple: 0,
+		ui.PoisonApple: 0,
+		ui.SpeedApple:  0,
+		ui.TimedApple:  0,
+	}}
 }
 
 func Test_IfAppleIsEatenThenPositionIsUpdatedAndItsNotEaten(t *testing.T) {
+	// the board is shared, so reseed it for the same placement however often the test runs
+	testBoard.rng.Seed(1)
 	a := apple{
 		AppleRenderer: ui.AppleRenderer{Pos: ui.Position{X: 10, Y: 10}},
 		eaten:         true,
@@ -83,6 +99,125 @@ func Test_Apples(t *testing.T) {
 	})
 }
 
+func Test_ApplePlacementIsReproducibleWithSameSeed(t *testing.T) {
+	newBoard := func(seed int64) *gameBoard {
+		return &gameBoard{
+			GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, 20, 20),
+			rng:               rand.New(rand.NewSource(seed)),
+			cfg:               normalApplesConfig(),
+		}
+	}
+
+	first := newApples(newBoard(42), 5)
+	second := newApples(newBoard(42), 5)
+	other := newApples(newBoard(43), 5)
+
+	require.Equal(t, first, second)
+	require.NotEqual(t, first, other)
+}
+
 func requireWithinBounds(t *testing.T, b *gameBoard, p ui.Position) {
 	require.Truef(t, b.IsInside(p), "%#v was not inside board", p)
 }
+
+func Test_AppleKinds(t *testing.T) {
+	newBoard := func(weights map[ui.AppleKind]int) *gameBoard {
+		return &gameBoard{
+			GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, 20, 20),
+			rng:               rand.New(rand.NewSource(1)),
+			cfg:               &Config{appleWeights: weights},
+		}
+	}
+
+	t.Run("kinds are spawned according to their weights", func(t *testing.T) {
+		b := newBoard(map[ui.AppleKind]int{ui.NormalApple: 0, ui.GoldenApple: 0, ui.PoisonApple: 1, ui.SpeedApple: 0, ui.TimedApple: 3})
+		seen := make(map[ui.AppleKind]int)
+
+		for range 400 {
+			seen[b.randomAppleKind()] += 1
+		}
+
+		require.Len(t, seen, 2)
+		require.InDelta(t, 300, seen[ui.TimedApple], 40)
+	})
+
+	t.Run("normal apples are spawned when every weight is zero", func(t *testing.T) {
+		b := newBoard(map[ui.AppleKind]int{ui.NormalApple: 0, ui.GoldenApple: 0, ui.PoisonApple: 0, ui.SpeedApple: 0, ui.TimedApple: 0})
+
+		require.Equal(t, ui.NormalApple, b.randomAppleKind())
+	})
+
+	t.Run("timed apples are replaced once they expire", func(t *testing.T) {
+		b := newBoard(map[ui.AppleKind]int{ui.NormalApple: 0, ui.GoldenApple: 0, ui.PoisonApple: 0, ui.SpeedApple: 0, ui.TimedApple: 1})
+		as := newApples(b, 1)
+		pos := as[0].Pos
+		require.Equal(t, timedAppleLifetime, as[0].ttl)
+
+		as.Update(b, timedAppleLifetime-time.Millisecond)
+		require.Equal(t, pos, as[0].Pos)
+
+		as.Update(b, time.Millisecond)
+		require.NotEqual(t, pos, as[0].Pos)
+		require.Equal(t, timedAppleLifetime, as[0].ttl)
+	})
+
+	t.Run("other kinds don't expire", func(t *testing.T) {
+		as := newApples(testBoard, 1)
+		pos := as[0].Pos
+
+		as.Update(testBoard, timedAppleLifetime*2)
+
+		require.Equal(t, pos, as[0].Pos)
+	})
+}
+
+func Test_ApplesOnAFullBoard(t *testing.T) {
+	board := newGameBoard(ui.Position{X: 0, Y: 0}, minWidth, minHeight, normalApplesConfig())
+	board.apples = nil
+	fill := func(leave int) {
+		board.snake.Body = nil
+		free := board.freeCells()
+		board.snake.Body = free[:len(free)-leave]
+	}
+
+	t.Run("are placed on the last free cell", func(t *testing.T) {
+		fill(1)
+		last := board.freeCells()[0]
+		a := newApple(board)
+
+		require.False(t, a.Hidden)
+		require.Equal(t, last, a.Pos)
+	})
+
+	t.Run("are hidden when there's no room left", func(t *testing.T) {
+		fill(0)
+		a := newApple(board)
+
+		require.True(t, a.Hidden)
+		require.True(t, board.cleared())
+	})
+
+	t.Run("hidden apples can't be eaten", func(t *testing.T) {
+		fill(0)
+		board.apples = apples{newApple(board)}
+		t.Cleanup(func() { board.apples = nil })
+		board.apples[0].Pos = board.snake.head()
+
+		points, eaten := board.snake.eat(board.occupied())
+
+		require.Zero(t, points)
+		require.Zero(t, eaten)
+	})
+
+	t.Run("come back once there's room", func(t *testing.T) {
+		fill(0)
+		board.apples = apples{newApple(board)}
+		t.Cleanup(func() { board.apples = nil })
+		board.snake.Body = board.snake.Body[1:]
+
+		board.apples.Update(board, 0)
+
+		require.False(t, board.apples[0].Hidden)
+		require.Empty(t, board.freeCells())
+	})
+}
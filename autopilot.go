@@ -0,0 +1,49 @@
+package main
+
+import (
+	"snake/grid"
+	"snake/ui"
+)
+
+const (
+	// AutopilotText is shown in the HUD while the autopilot is steering.
+	AutopilotText = "autopilot"
+	// hintLength is the number of cells of the suggested path shown by the hint.
+	hintLength = 5
+)
+
+// toggleAutopilot hands the first player's snake over to a bot, or takes it back.
+func (b *gameBoard) toggleAutopilot() {
+	if b.autopilot != nil {
+		b.autopilot = nil
+		b.SetMode(b.wallMode.String())
+		return
+	}
+	b.autopilot = newBot(b.snake, HardBot)
+	b.SetMode(AutopilotText)
+}
+
+// suggestedPath returns the first few cells of the shortest path from the first player's
+// snake to an apple worth eating.
+func (b *gameBoard) suggestedPath() []ui.Position {
+	path, _ := pathToApple(b, b.grid(), b.snake.head())
+	return path[:min(len(path), hintLength)]
+}
+
+// grid returns the cells of the board the snakes can move through, with the walls and every
+// snake blocked.
+func (b *gameBoard) grid() *grid.Grid {
+	ret := grid.New(
+		ui.Position{X: b.Left() + 1, Y: b.Top() + 1},
+		b.Right()-b.Left()-1,
+		b.Bottom()-b.Top()-1,
+	)
+	ret.SetWrap(b.wallMode == WrapWalls)
+	o := b.occupied()
+	for i, c := range o.cells {
+		if c.wall || c.owner != nil {
+			ret.Block(ui.Position{X: o.topLeft.X + i%o.width, Y: o.topLeft.Y + i/o.width})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"snake/ui"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Autopilot(t *testing.T) {
+	var g *game
+
+	setup := func() {
+		cfg := normalApplesConfig()
+		// a fixed seed puts the apples in the same place every run
+		cfg.seed = 1
+		g = newSnakeGame(cfg, 30, 30)
+		g.currentState.handle(g, StartGame)
+	}
+
+	t.Run("toggles with its key", func(t *testing.T) {
+		setup()
+
+		g.Handle(keyPress(tcell.KeyRune, 'p'))
+		require.NotNil(t, g.gameBoard.autopilot)
+		require.Equal(t, AutopilotText, g.gameBoard.ModeBox().Text())
+
+		g.Handle(keyPress(tcell.KeyRune, 'p'))
+		require.Nil(t, g.gameBoard.autopilot)
+		require.Equal(t, SolidWalls.String(), g.gameBoard.ModeBox().Text())
+	})
+
+	t.Run("eats apples without losing a life", func(t *testing.T) {
+		setup()
+		g.Handle(keyPress(tcell.KeyRune, 'p'))
+
+		for range 60 * 30 {
+			g.Update(TickDuration)
+		}
+
+		assert.NotZero(t, g.applesEaten)
+		assert.Equal(t, DefaultNumberOfLives, g.remainingLives)
+	})
+
+	t.Run("replays the autopilot", func(t *testing.T) {
+		const ticks = 60 * 10
+		setup()
+		g.Handle(keyPress(tcell.KeyRune, 'p'))
+		for range ticks {
+			g.Update(TickDuration)
+		}
+
+		r := startReplay(t, g.recording)
+		for range ticks {
+			r.Update(TickDuration)
+		}
+
+		assert.Equal(t, g.gameBoard.snake.Body, r.gameBoard.snake.Body)
+	})
+}
+
+func Test_Hint(t *testing.T) {
+	var b *gameBoard
+
+	setup := func(apple ui.Position) {
+		b = newGameBoard(ui.Position{}, 30, 30, normalApplesConfig())
+		b.apples = apples{{AppleRenderer: ui.AppleRenderer{Pos: apple}}}
+	}
+
+	t.Run("is hidden until toggled", func(t *testing.T) {
+		setup(ui.Position{X: 20, Y: 10})
+		require.False(t, b.hint.Visible)
+
+		b.notify(ToggleHint)
+
+		require.True(t, b.hint.Visible)
+		require.NotEmpty(t, b.hint.Cells)
+	})
+
+	t.Run("shows the first few cells towards the apple", func(t *testing.T) {
+		head := ui.Position{X: 10, Y: 12}
+		setup(ui.Position{X: 20, Y: 12})
+		b.snake.Body = []ui.Position{{X: 8, Y: 12}, {X: 9, Y: 12}, head}
+
+		b.notify(ToggleHint)
+
+		require.Len(t, b.hint.Cells, hintLength)
+		for i, c := range b.hint.Cells {
+			require.Equal(t, ui.Position{X: head.X + i + 1, Y: head.Y}, c)
+		}
+	})
+
+	t.Run("shows the whole path when the apple is close", func(t *testing.T) {
+		head := ui.Position{X: 10, Y: 12}
+		setup(ui.Position{X: 12, Y: 12})
+		b.snake.Body = []ui.Position{{X: 8, Y: 12}, {X: 9, Y: 12}, head}
+
+		b.notify(ToggleHint)
+
+		require.Equal(t, []ui.Position{{X: 11, Y: 12}, {X: 12, Y: 12}}, b.hint.Cells)
+	})
+
+	t.Run("leaves the snake's direction alone", func(t *testing.T) {
+		setup(ui.Position{X: 5, Y: 25})
+		dir := b.snake.dir
+
+		b.notify(ToggleHint)
+		b.Update(&game{gameBoard: b, stats: stats{remainingLives: 1}}, TickDuration)
+
+		require.Equal(t, dir, b.snake.dir)
+	})
+
+	t.Run("board grid blocks walls and snakes", func(t *testing.T) {
+		setup(ui.Position{X: 5, Y: 25})
+		grid := b.grid()
+
+		require.True(t, grid.Blocked(b.snake.head()))
+		require.True(t, grid.Blocked(ui.Position{X: b.Left(), Y: 10}))
+		require.False(t, grid.Blocked(ui.Position{X: b.Left() + 1, Y: 10}))
+	})
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"snake/grid"
+	"snake/ui"
+	"time"
+)
+
+// Difficulty controls how a bot picks its moves.
+type Difficulty int
+
+const (
+	// EasyBot chases the closest apple, only looking one move ahead.
+	EasyBot Difficulty = iota
+	// MediumBot follows the shortest path to the nearest reachable apple.
+	MediumBot
+	// HardBot follows the shortest path to an apple, unless taking it leaves the bot too
+	// little room to fit its body, in which case it heads for the most open space.
+	HardBot
+)
+
+var difficulties = []Difficulty{EasyBot, MediumBot, HardBot}
+
+func (d Difficulty) String() string {
+	switch d {
+	case EasyBot:
+		return "easy"
+	case MediumBot:
+		return "medium"
+	case HardBot:
+		return "hard"
+	default:
+		return fmt.Sprintf("unrecognized difficulty: %d", int(d))
+	}
+}
+
+func (d Difficulty) MarshalText() ([]byte, error) {
+	switch d {
+	case EasyBot, MediumBot, HardBot:
+		return []byte(d.String()), nil
+	default:
+		return nil, fmt.Errorf("unrecognized difficulty: %d", int(d))
+	}
+}
+
+func (d *Difficulty) UnmarshalText(text []byte) error {
+	for _, difficulty := range difficulties {
+		if difficulty.String() == string(text) {
+			*d = difficulty
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized difficulty: %q", text)
+}
+
+// directions are the moves a snake can make, in the order bots prefer them when moves are
+// equally good.
+var directions = []direction{up, right, down, left}
+
+// moveEvents are the events that turn a snake in each direction.
+var moveEvents = map[direction]Event{
+	up:    MoveUp,
+	right: MoveRight,
+	down:  MoveDown,
+	left:  MoveLeft,
+}
+
+// bot steers a snake by sending it the same events a player's keys would.
+type bot struct {
+	snake      *snake
+	difficulty Difficulty
+	// controls receives the bot's moves, which is normally the snake itself.
+	controls EventListener
+}
+
+func newBot(s *snake, difficulty Difficulty) *bot {
+	return &bot{snake: s, difficulty: difficulty, controls: s}
+}
+
+// Update picks the bot's next move, if its snake is about to move.
+func (b *bot) Update(board *gameBoard, delta time.Duration) {
+	if b.snake.moveTimer-delta > 0 {
+		return
+	}
+	b.controls.Notify(moveEvents[b.choose(board)])
+}
+
+// choose returns the direction the snake should move in next.
+func (b *bot) choose(board *gameBoard) direction {
+	g := board.grid()
+	head := b.snake.head()
+	safe := b.safeMoves(g)
+	if len(safe) == 0 {
+		return b.snake.dir
+	}
+
+	switch b.difficulty {
+	case EasyBot:
+		return b.closestTo(board, g, safe)
+	case MediumBot:
+		if d, ok := b.towardsApple(board, g); ok {
+			return d
+		}
+		return safe[0]
+	default:
+		if d, ok := b.towardsApple(board, g); ok {
+			if g.FloodFill(step(g, head, d)) >= len(b.snake.Body) {
+				return d
+			}
+		}
+		return roomiest(g, head, safe)
+	}
+}
+
+// towardsApple returns the first move along the shortest path to an apple.
+func (b *bot) towardsApple(board *gameBoard, g *grid.Grid) (direction, bool) {
+	path, ok := pathToApple(board, g, b.snake.head())
+	if !ok {
+		return 0, false
+	}
+	for _, d := range directions {
+		if step(g, b.snake.head(), d) == path[0] && !d.isOpposite(b.snake.dir) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// safeMoves returns the directions the snake can move in without crashing straight away.
+func (b *bot) safeMoves(g *grid.Grid) []direction {
+	var ret []direction
+	for _, d := range directions {
+		if !d.isOpposite(b.snake.dir) && !g.Blocked(step(g, b.snake.head(), d)) {
+			ret = append(ret, d)
+		}
+	}
+	return ret
+}
+
+// closestTo returns the move that brings the snake closest to an apple, ignoring anything in
+// the way.
+func (b *bot) closestTo(board *gameBoard, g *grid.Grid, moves []direction) direction {
+	best, bestDist := moves[0], -1
+	for _, d := range moves {
+		next := step(g, b.snake.head(), d)
+		board.apples.ForEach(func(a *apple) {
+			if !wanted(a) {
+				return
+			}
+			if dist := g.Distance(next, a.Pos); bestDist < 0 || dist < bestDist {
+				best, bestDist = d, dist
+			}
+		})
+	}
+	return best
+}
+
+// pathToApple returns the shortest path from head to the nearest apple worth eating.
+func pathToApple(board *gameBoard, g *grid.Grid, head ui.Position) ([]ui.Position, bool) {
+	targets := make(map[ui.Position]struct{})
+	board.apples.ForEach(func(a *apple) {
+		if wanted(a) {
+			targets[a.Pos] = struct{}{}
+		}
+	})
+	return g.BFS(head, func(pos ui.Position) bool {
+		_, ok := targets[pos]
+		return ok
+	})
+}
+
+// roomiest returns the move that leaves the snake the most space to move around in.
+func roomiest(g *grid.Grid, head ui.Position, moves []direction) direction {
+	best, bestSpace := moves[0], -1
+	for _, d := range moves {
+		if n := g.FloodFill(step(g, head, d)); n > bestSpace {
+			best, bestSpace = d, n
+		}
+	}
+	return best
+}
+
+// step returns the cell reached by moving from pos in direction d.
+func step(g *grid.Grid, pos ui.Position, d direction) ui.Position {
+	dx, dy := d.offset()
+	return g.Step(pos, dx, dy)
+}
+
+// wanted reports whether an apple is worth chasing, which rules out poison.
+func wanted(a *apple) bool {
+	return !a.eaten && a.visible() && a.Kind != ui.PoisonApple
+}
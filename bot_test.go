@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"snake/ui"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type spyListener struct {
+	events []Event
+}
+
+func (s *spyListener) Notify(event Event) {
+	s.events = append(s.events, event)
+}
+
+func Test_Difficulty(t *testing.T) {
+	for _, d := range difficulties {
+		t.Run("round trips "+d.String()+" through text", func(t *testing.T) {
+			text, err := d.MarshalText()
+			require.NoError(t, err)
+
+			var act Difficulty
+			require.NoError(t, act.UnmarshalText(text))
+			require.Equal(t, d, act)
+		})
+	}
+
+	t.Run("rejects unknown difficulties", func(t *testing.T) {
+		var d Difficulty
+		require.Error(t, d.UnmarshalText([]byte("impossible")))
+	})
+}
+
+func Test_Bot(t *testing.T) {
+	var board *gameBoard
+	var s *snake
+
+	// setup places a bot's snake heading right along the middle row of an empty board, with
+	// its head at x and a single apple at the given position.
+	setup := func(x int, apple ui.Position) {
+		board = &gameBoard{
+			GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, 20, 15),
+			obstacles:         make(map[ui.Position]struct{}),
+		}
+		y := board.Center().Y
+		s = newSnakeOfLength(ui.Position{X: x, Y: y}, 3)
+		s.player = firstBot
+		board.others = []*snake{s}
+		board.apples = apples{{AppleRenderer: ui.AppleRenderer{Pos: apple}}}
+	}
+	wall := func(positions ...ui.Position) {
+		for _, p := range positions {
+			board.obstacles[p] = struct{}{}
+		}
+	}
+
+	t.Run("only moves when the snake is about to move", func(t *testing.T) {
+		setup(5, ui.Position{X: 10, Y: 3})
+		spy := &spyListener{}
+		b := &bot{snake: s, controls: spy}
+		s.moveTimer = 2 * TickDuration
+
+		b.Update(board, TickDuration)
+		require.Empty(t, spy.events)
+
+		s.moveTimer = TickDuration
+		b.Update(board, TickDuration)
+		require.Len(t, spy.events, 1)
+	})
+
+	t.Run("drives the snake through its events", func(t *testing.T) {
+		setup(5, ui.Position{X: 5, Y: 3})
+
+		newBot(s, EasyBot).Update(board, TickDuration)
+
+		require.Equal(t, up, s.dir)
+	})
+
+	for _, d := range difficulties {
+		t.Run(d.String()+" heads for the apple", func(t *testing.T) {
+			setup(5, ui.Position{X: 5, Y: board.Bottom() - 2})
+
+			require.Equal(t, down, newBot(s, d).choose(board))
+		})
+
+		t.Run(d.String()+" avoids running into a wall", func(t *testing.T) {
+			setup(5, ui.Position{X: 15, Y: board.Center().Y})
+			wall(ui.Position{X: 6, Y: board.Center().Y})
+
+			require.NotEqual(t, right, newBot(s, d).choose(board))
+		})
+	}
+
+	t.Run("easy ignores walls in the way of the apple", func(t *testing.T) {
+		setup(5, ui.Position{X: 5, Y: 0})
+		y := board.Center().Y
+		board.apples[0].Pos = ui.Position{X: 5, Y: y - 3}
+		// the cell above the head is a dead end, so the apple can only be reached from below
+		wall(ui.Position{X: 4, Y: y - 1}, ui.Position{X: 5, Y: y - 2}, ui.Position{X: 6, Y: y - 1},
+			ui.Position{X: 6, Y: y})
+
+		require.Equal(t, up, newBot(s, EasyBot).choose(board))
+		require.Equal(t, down, newBot(s, MediumBot).choose(board))
+	})
+
+	t.Run("hard avoids apples in dead ends", func(t *testing.T) {
+		setup(5, ui.Position{X: 5, Y: 0})
+		y := board.Center().Y
+		// the apple sits at the end of a one-cell pocket above the head, too small for the
+		// snake to turn around in
+		board.apples[0].Pos = ui.Position{X: 5, Y: y - 1}
+		wall(ui.Position{X: 4, Y: y - 1}, ui.Position{X: 6, Y: y - 1}, ui.Position{X: 5, Y: y - 2})
+
+		require.Equal(t, up, newBot(s, MediumBot).choose(board))
+		require.NotEqual(t, up, newBot(s, HardBot).choose(board))
+	})
+
+	t.Run("skips poison apples", func(t *testing.T) {
+		setup(5, ui.Position{X: 5, Y: 3})
+		board.apples[0].Kind = ui.PoisonApple
+
+		_, ok := pathToApple(board, board.grid(), s.head())
+
+		require.False(t, ok)
+	})
+
+	t.Run("wraps around the board when walls wrap", func(t *testing.T) {
+		setup(5, ui.Position{X: board.Right() - 1, Y: 0})
+		board.wallMode = WrapWalls
+		board.apples[0].Pos = ui.Position{X: board.Right() - 1, Y: board.Center().Y}
+		s.dir = left
+		s.Body = []ui.Position{{X: 3, Y: board.Center().Y}, {X: 2, Y: board.Center().Y}, {X: 1, Y: board.Center().Y}}
+
+		require.Equal(t, left, newBot(s, MediumBot).choose(board))
+	})
+}
+
+func Test_GameWithBots(t *testing.T) {
+	newGame := func() *game {
+		cfg := normalApplesConfig()
+		cfg.bots = 2
+		cfg.botDifficulty = HardBot
+		g := newSnakeGame(cfg, 40, 30)
+		g.currentState.handle(g, StartGame)
+		return g
+	}
+
+	t.Run("adds the configured bots", func(t *testing.T) {
+		g := newGame()
+
+		require.Len(t, g.gameBoard.bots, 2)
+		require.Len(t, g.gameBoard.others, 2)
+		require.Nil(t, g.gameBoard.playerTwo())
+	})
+
+	t.Run("bots score for themselves", func(t *testing.T) {
+		g := newGame()
+		for range 60 * 20 {
+			g.Update(TickDuration)
+		}
+
+		eaten := g.botStats[0].applesEaten + g.botStats[1].applesEaten
+		assert.NotZero(t, eaten)
+	})
+
+	t.Run("bots don't run out of lives", func(t *testing.T) {
+		g := newGame()
+		s := g.gameBoard.bots[0].snake
+
+		s.die(g.gameBoard, g)
+
+		require.Equal(t, g.gameBoard.startFor(s), s.head())
+		require.False(t, g.gameOver())
+	})
+
+	t.Run("replays games with bots", func(t *testing.T) {
+		const ticks = 60 * 10
+		g := recordGame(&Config{bots: 2, botDifficulty: MediumBot}, ticks)
+
+		r := startReplay(t, g.recording)
+		for range ticks {
+			r.Update(TickDuration)
+		}
+
+		for i := range g.gameBoard.others {
+			assert.Equal(t, g.gameBoard.others[i].Body, r.gameBoard.others[i].Body)
+		}
+		assert.Equal(t, g.botStats, r.botStats)
+	})
+
+	t.Run("levels can't be played with bots", func(t *testing.T) {
+		g := newGame()
+
+		require.ErrorIs(t, g.setLevel(loadTestLevel(t)), ErrSinglePlayerOnly)
+	})
+
+	t.Run("reads bots from json", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"bots": 3, "botDifficulty": "hard"}`), &cfg))
+
+		require.Equal(t, 3, cfg.Bots())
+		require.Equal(t, HardBot, cfg.BotDifficulty())
+	})
+
+	t.Run("rejects too many bots", func(t *testing.T) {
+		var cfg Config
+		require.Error(t, json.Unmarshal([]byte(`{"bots": 5}`), &cfg))
+	})
+}
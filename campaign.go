@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"snake/ui"
+	"time"
+)
+
+const (
+	lengthGoalFormat  = "Length %d/%d"
+	applesGoalFormat  = "Apples %d/%d"
+	secondsGoalFormat = "Time %d/%ds"
+
+	progressDir  = "snake"
+	progressFile = "progress.json"
+)
+
+// Goal is what has to be done to complete a level of a campaign. Exactly one of its fields
+// is set.
+type Goal struct {
+	Length  int `json:"length,omitempty"`
+	Apples  int `json:"apples,omitempty"`
+	Seconds int `json:"seconds,omitempty"`
+}
+
+// goalProgress is how far the player has got in the current level.
+type goalProgress struct {
+	length  int
+	apples  uint
+	elapsed time.Duration
+}
+
+func (g Goal) validate() error {
+	set := 0
+	for _, v := range []int{g.Length, g.Apples, g.Seconds} {
+		if v < 0 {
+			return errors.New("goal can't be negative")
+		}
+		if v > 0 {
+			set += 1
+		}
+	}
+	if set != 1 {
+		return errors.New("goal needs exactly one of length, apples or seconds")
+	}
+	return nil
+}
+
+func (g Goal) met(p goalProgress) bool {
+	switch {
+	case g.Length > 0:
+		return p.length >= g.Length
+	case g.Apples > 0:
+		return p.apples >= uint(g.Apples)
+	default:
+		return p.elapsed >= time.Duration(g.Seconds)*time.Second
+	}
+}
+
+// status describes the progress towards the goal, short enough to fit in the HUD.
+func (g Goal) status(p goalProgress) string {
+	switch {
+	case g.Length > 0:
+		return fmt.Sprintf(lengthGoalFormat, min(p.length, g.Length), g.Length)
+	case g.Apples > 0:
+		return fmt.Sprintf(applesGoalFormat, min(p.apples, uint(g.Apples)), g.Apples)
+	default:
+		return fmt.Sprintf(secondsGoalFormat, min(int(p.elapsed/time.Second), g.Seconds), g.Seconds)
+	}
+}
+
+// CampaignLevel is a level of a campaign along with its goal. The level file is relative to
+// the campaign file.
+type CampaignLevel struct {
+	File  string `json:"level"`
+	Goal  Goal   `json:"goal"`
+	level *Level
+}
+
+// Name returns the name of the level, falling back to its file.
+func (c *CampaignLevel) Name() string {
+	if c.level != nil && c.level.Name != "" {
+		return c.level.Name
+	}
+	return c.File
+}
+
+// Campaign is an ordered list of levels, where each level is unlocked by completing the one
+// before it.
+type Campaign struct {
+	Name   string          `json:"name"`
+	Levels []CampaignLevel `json:"levels"`
+}
+
+// LoadCampaign loads a campaign along with all of its levels.
+func LoadCampaign(filename string) (*Campaign, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open: %w", err)
+	}
+	defer file.Close()
+
+	var ret Campaign
+	if err = json.NewDecoder(file).Decode(&ret); err != nil {
+		return nil, err
+	}
+	if ret.Name == "" {
+		return nil, errors.New("campaign has no name")
+	}
+	if len(ret.Levels) == 0 {
+		return nil, errors.New("campaign has no levels")
+	}
+	dir := filepath.Dir(filename)
+	for i := range ret.Levels {
+		l := &ret.Levels[i]
+		if err = l.Goal.validate(); err != nil {
+			return nil, fmt.Errorf("level %d: %w", i+1, err)
+		}
+		if l.level, err = LoadLevel(filepath.Join(dir, l.File)); err != nil {
+			return nil, fmt.Errorf("level %d: %w", i+1, err)
+		}
+	}
+	return &ret, nil
+}
+
+// CampaignProgress remembers how many levels of each campaign have been completed.
+type CampaignProgress struct {
+	Completed map[string]int `json:"completed"`
+}
+
+func (p *CampaignProgress) completed(c *Campaign) int {
+	return min(p.Completed[c.Name], len(c.Levels))
+}
+
+// complete marks the level at index i as completed, unlocking the next one.
+func (p *CampaignProgress) complete(c *Campaign, i int) {
+	if p.Completed == nil {
+		p.Completed = make(map[string]int)
+	}
+	p.Completed[c.Name] = max(p.Completed[c.Name], i+1)
+}
+
+// unlocked reports whether the level at index i can be played.
+func (p *CampaignProgress) unlocked(c *Campaign, i int) bool {
+	return i <= p.completed(c)
+}
+
+// Save writes the progress to a file, creating its directory if needed.
+func (p *CampaignProgress) Save(filename string) error {
+	data, err := json.MarshalIndent(p, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err = os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+// LoadCampaignProgress loads progress from a file. A missing file means nothing has been
+// completed yet.
+func LoadCampaignProgress(filename string) (*CampaignProgress, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &CampaignProgress{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open: %w", err)
+	}
+	var ret CampaignProgress
+	if err = json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// DefaultProgressFile returns where campaign progress is kept in the user's config directory.
+func DefaultProgressFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, progressDir, progressFile), nil
+}
+
+// campaignRun is a campaign being played.
+type campaignRun struct {
+	campaign     *Campaign
+	progress     *CampaignProgress
+	progressFile string
+	current      int
+	view         *ui.LevelSelectView
+	// saveErr holds the last error from saving progress, so it can be reported on exit.
+	saveErr error
+}
+
+func (r *campaignRun) level() *CampaignLevel {
+	return &r.campaign.Levels[r.current]
+}
+
+// setCampaign switches the game to playing a campaign, starting from the level select. Progress
+// is saved to progressFile as levels are completed, unless it's empty.
+func (g *game) setCampaign(c *Campaign, progress *CampaignProgress, progressFile string) error {
+	if len(g.gameBoard.others) > 0 {
+		return ErrSinglePlayerOnly
+	}
+	for i := range c.Levels {
+		if err := c.Levels[i].level.fits(g.gameBoard.snake.startingLength); err != nil {
+			return err
+		}
+	}
+	g.campaign = &campaignRun{
+		campaign:     c,
+		progress:     progress,
+		progressFile: progressFile,
+		view:         ui.NewLevelSelectView(c.Name),
+	}
+	g.campaign.view.Resize(g.gameBoard.Width(), g.gameBoard.Height())
+	g.AddView(ui.LevelSelectViewName, g.campaign.view)
+	g.currentState = g.menu()
+	return nil
+}
+
+// showLevelSelect lists the campaign's levels, selecting the first one that hasn't been
+// completed.
+func (g *game) showLevelSelect() {
+	r := g.campaign
+	entries := make([]ui.LevelSelectEntry, len(r.campaign.Levels))
+	for i := range r.campaign.Levels {
+		entries[i] = ui.LevelSelectEntry{
+			Name:      r.campaign.Levels[i].Name(),
+			Locked:    !r.progress.unlocked(r.campaign, i),
+			Completed: i < r.progress.completed(r.campaign),
+		}
+	}
+	r.view.SetEntries(entries)
+	r.view.Select(r.progress.completed(r.campaign))
+	_ = g.SwitchView(ui.LevelSelectViewName)
+}
+
+// startLevel starts a new game on the campaign level at index i.
+func (g *game) startLevel(i int) {
+	g.campaign.current = i
+	// setCampaign has already checked every level fits the snake.
+	_ = g.setLevel(g.campaign.level().level)
+	g.reset()
+	g.Manager.HideModal()
+	_ = g.SwitchView(gameBoardViewName)
+	g.currentState = &playingState{board: g.gameBoard}
+}
+
+// completeLevel records the current level as completed.
+func (g *game) completeLevel() {
+	r := g.campaign
+	r.progress.complete(r.campaign, r.current)
+	if r.progressFile != "" {
+		r.saveErr = r.progress.Save(r.progressFile)
+	}
+}
+
+func (g *game) goalProgress() goalProgress {
+	return goalProgress{
+		length:  g.gameBoard.snake.Length(),
+		apples:  g.applesEaten,
+		elapsed: g.playTime,
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"snake/ui"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Goal(t *testing.T) {
+	t.Run("validate", func(t *testing.T) {
+		require.NoError(t, Goal{Length: 5}.validate())
+		require.NoError(t, Goal{Apples: 5}.validate())
+		require.NoError(t, Goal{Seconds: 5}.validate())
+		require.Error(t, Goal{}.validate())
+		require.Error(t, Goal{Length: 5, Apples: 5}.validate())
+		require.Error(t, Goal{Length: -1}.validate())
+	})
+
+	t.Run("met", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			goal     Goal
+			progress goalProgress
+			exp      bool
+		}{
+			{name: "length reached", goal: Goal{Length: 5}, progress: goalProgress{length: 5}, exp: true},
+			{name: "length not reached", goal: Goal{Length: 5}, progress: goalProgress{length: 4}},
+			{name: "apples eaten", goal: Goal{Apples: 2}, progress: goalProgress{apples: 3}, exp: true},
+			{name: "apples not eaten", goal: Goal{Apples: 2}, progress: goalProgress{apples: 1}},
+			{name: "survived", goal: Goal{Seconds: 2}, progress: goalProgress{elapsed: 2 * time.Second}, exp: true},
+			{name: "not survived", goal: Goal{Seconds: 2}, progress: goalProgress{elapsed: time.Second}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				require.Equal(t, tt.exp, tt.goal.met(tt.progress))
+			})
+		}
+	})
+
+	t.Run("status", func(t *testing.T) {
+		p := goalProgress{length: 4, apples: 1, elapsed: 2500 * time.Millisecond}
+
+		require.Equal(t, "Length 4/10", Goal{Length: 10}.status(p))
+		require.Equal(t, "Apples 1/3", Goal{Apples: 3}.status(p))
+		require.Equal(t, "Time 2/30s", Goal{Seconds: 30}.status(p))
+		require.Equal(t, "Length 3/3", Goal{Length: 3}.status(p))
+	})
+}
+
+func Test_LoadCampaign(t *testing.T) {
+	writeCampaign := func(t *testing.T, campaign string) string {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "one.txt"), []byte(testLevel("name: One")), 0o644))
+		filename := filepath.Join(dir, "campaign.json")
+		require.NoError(t, os.WriteFile(filename, []byte(campaign), 0o644))
+		return filename
+	}
+
+	t.Run("loads levels relative to the campaign", func(t *testing.T) {
+		c, err := LoadCampaign(writeCampaign(t, `{"name": "Test", "levels": [{"level": "one.txt", "goal": {"apples": 3}}]}`))
+
+		require.NoError(t, err)
+		require.Equal(t, "Test", c.Name)
+		require.Len(t, c.Levels, 1)
+		require.Equal(t, "One", c.Levels[0].Name())
+		require.Equal(t, Goal{Apples: 3}, c.Levels[0].Goal)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			campaign string
+		}{
+			{name: "no name", campaign: `{"levels": [{"level": "one.txt", "goal": {"apples": 3}}]}`},
+			{name: "no levels", campaign: `{"name": "Test"}`},
+			{name: "missing level", campaign: `{"name": "Test", "levels": [{"level": "two.txt", "goal": {"apples": 3}}]}`},
+			{name: "no goal", campaign: `{"name": "Test", "levels": [{"level": "one.txt"}]}`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := LoadCampaign(writeCampaign(t, tt.campaign))
+
+				require.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("bundled campaign", func(t *testing.T) {
+		_, err := LoadCampaign(filepath.Join(LevelsDir, "campaign.json"))
+
+		require.NoError(t, err)
+	})
+}
+
+func Test_CampaignProgress(t *testing.T) {
+	c := &Campaign{Name: "Test", Levels: make([]CampaignLevel, 3)}
+
+	t.Run("only the first level is unlocked at the start", func(t *testing.T) {
+		p := &CampaignProgress{}
+
+		require.True(t, p.unlocked(c, 0))
+		require.False(t, p.unlocked(c, 1))
+	})
+
+	t.Run("completing a level unlocks the next", func(t *testing.T) {
+		p := &CampaignProgress{}
+
+		p.complete(c, 0)
+
+		require.Equal(t, 1, p.completed(c))
+		require.True(t, p.unlocked(c, 1))
+		require.False(t, p.unlocked(c, 2))
+	})
+
+	t.Run("replaying an earlier level keeps progress", func(t *testing.T) {
+		p := &CampaignProgress{}
+		p.complete(c, 1)
+
+		p.complete(c, 0)
+
+		require.Equal(t, 2, p.completed(c))
+	})
+
+	t.Run("survives a round trip through a file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "snake", "progress.json")
+		p := &CampaignProgress{}
+		p.complete(c, 1)
+
+		require.NoError(t, p.Save(filename))
+		act, err := LoadCampaignProgress(filename)
+
+		require.NoError(t, err)
+		require.Equal(t, p, act)
+	})
+
+	t.Run("missing file has no progress", func(t *testing.T) {
+		p, err := LoadCampaignProgress(filepath.Join(t.TempDir(), "progress.json"))
+
+		require.NoError(t, err)
+		require.Equal(t, 0, p.completed(c))
+	})
+}
+
+func Test_CampaignStates(t *testing.T) {
+	setup := func(t *testing.T) (*game, string) {
+		c := &Campaign{
+			Name: "Test",
+			Levels: []CampaignLevel{
+				{File: "one.txt", Goal: Goal{Seconds: 1}, level: loadTestLevel(t, "name: One")},
+				{File: "two.txt", Goal: Goal{Apples: 1}, level: loadTestLevel(t, "name: Two")},
+			},
+		}
+		filename := filepath.Join(t.TempDir(), "progress.json")
+		g := newSnakeGame(normalApplesConfig(), 30, 30)
+		require.NoError(t, g.setCampaign(c, &CampaignProgress{}, filename))
+		return g, filename
+	}
+	// updateFor runs the game for at least the given duration.
+	updateFor := func(g *game, d time.Duration) {
+		for range d/TickDuration + 1 {
+			g.Update(TickDuration)
+		}
+	}
+
+	t.Run("starts on the level select", func(t *testing.T) {
+		g, _ := setup(t)
+
+		require.IsType(t, new(levelSelectState), g.currentState)
+		require.Equal(t, ui.LevelSelectViewName, g.ActiveViewName())
+	})
+
+	t.Run("locked levels can't be started", func(t *testing.T) {
+		g, _ := setup(t)
+
+		g.handleEvent(MoveDown)
+		g.handleEvent(StartGame)
+
+		require.IsType(t, new(levelSelectState), g.currentState)
+	})
+
+	t.Run("starting a level plays it", func(t *testing.T) {
+		g, _ := setup(t)
+
+		g.handleEvent(StartGame)
+
+		require.IsType(t, new(playingState), g.currentState)
+		require.Equal(t, gameBoardViewName, g.ActiveViewName())
+		require.Equal(t, g.campaign.campaign.Levels[0].level, g.gameBoard.level)
+	})
+
+	t.Run("meeting the goal completes the level and saves progress", func(t *testing.T) {
+		g, filename := setup(t)
+		g.handleEvent(StartGame)
+
+		updateFor(g, time.Second)
+
+		require.IsType(t, new(levelCompleteState), g.currentState)
+		p, err := LoadCampaignProgress(filename)
+		require.NoError(t, err)
+		require.Equal(t, 1, p.completed(g.campaign.campaign))
+	})
+
+	t.Run("goal progress is shown in the HUD", func(t *testing.T) {
+		g, _ := setup(t)
+		g.handleEvent(StartGame)
+
+		updateFor(g, time.Second/2)
+
+		require.Contains(t, g.gameBoard.GoalBox().Text(), "Time 0/1s")
+		require.Equal(t, g.gameBoard.wallMode.String(), g.gameBoard.ModeBox().Text(), "the wall mode is still shown")
+	})
+
+	t.Run("completing a level moves on to the next", func(t *testing.T) {
+		g, _ := setup(t)
+		g.handleEvent(StartGame)
+		updateFor(g, time.Second)
+
+		updateFor(g, LevelTransitionDelay)
+
+		require.IsType(t, new(playingState), g.currentState)
+		require.Equal(t, 1, g.campaign.current)
+		require.Equal(t, uint(0), g.applesEaten)
+	})
+
+	t.Run("completing the last level completes the campaign", func(t *testing.T) {
+		g, _ := setup(t)
+		g.campaign.progress.complete(g.campaign.campaign, 0)
+		g.showLevelSelect()
+		require.Equal(t, 1, g.campaign.view.Selected())
+		g.handleEvent(StartGame)
+		head := g.gameBoard.snake.head()
+		g.gameBoard.apples[0].Pos = ui.Position{X: head.X + 1, Y: head.Y}
+
+		g.Update(TickDuration)
+		require.IsType(t, new(levelCompleteState), g.currentState)
+
+		updateFor(g, LevelTransitionDelay)
+		require.IsType(t, new(campaignCompleteState), g.currentState)
+
+		updateFor(g, MainMenuTransitionDelay)
+		require.IsType(t, new(levelSelectState), g.currentState)
+		require.Equal(t, ui.LevelSelectViewName, g.ActiveViewName())
+	})
+
+	t.Run("game over returns to the level select", func(t *testing.T) {
+		g, _ := setup(t)
+		g.handleEvent(StartGame)
+		g.remainingLives = 0
+
+		updateFor(g, MainMenuTransitionDelay+TickDuration)
+
+		require.IsType(t, new(levelSelectState), g.currentState)
+	})
+}
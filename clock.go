@@ -0,0 +1,26 @@
+package main
+
+import "time"
+
+// Clock is the source of time for the game loop. Production code uses the
+// system clock while tests can substitute a virtual clock to drive the loop
+// deterministically.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// SystemClock returns a Clock backed by the time package.
+func SystemClock() Clock {
+	return systemClock{}
+}
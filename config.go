@@ -2,38 +2,197 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"snake/ui"
 )
 
+// ConfigFile is the name of the config file, which is looked for in the directories listed by
+// ConfigPaths.
+const ConfigFile = "config.json"
+
 const (
 	DefaultMaxNumberOfApples      = 10
 	DefaultNumberOfLives     uint = 3
 	DefaultStartingLength         = 3
+	DefaultBoardWidth             = 39
+	DefaultBoardHeight            = 39
+	DefaultPlayers                = 1
+	MaxPlayers                    = 2
+	MaxBots                       = 4
+)
+
+// DefaultAppleWeights are the relative chances of each kind of apple being spawned.
+var DefaultAppleWeights = map[ui.AppleKind]int{
+	ui.NormalApple: 80,
+	ui.GoldenApple: 5,
+	ui.PoisonApple: 5,
+	ui.SpeedApple:  5,
+	ui.TimedApple:  5,
+}
+
+var (
+	// ErrUnknownField is reported for fields of a configuration file that the game doesn't use,
+	// which are most likely misspelled.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrZeroField is reported for fields set to zero where zero stands for the default.
+	ErrZeroField = errors.New("can't be zero, leave it out to use the default")
+	errNegative  = errors.New("can't be negative")
 )
 
+// FieldError is a problem with one field of a configuration, found at Path, the key of the
+// field in the configuration file. Keys inside an object are joined to its key with a dot.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
 // Config holds the configuration settings for the game
 type Config struct {
 	maxNumberOfApples   int
 	numberOfLives       uint
 	snakeStartingLength int
+	seed                int64
+	boardWidth          int
+	boardHeight         int
+	fillTerminal        bool
+	wallMode            WallMode
+	appleWeights        map[ui.AppleKind]int
+	players             int
+	bots                int
+	botDifficulty       Difficulty
+	applePlacement      Placement
 }
 
-// UnmarshalJSON updates the configuration using the provided JSON data.
-func (c *Config) UnmarshalJSON(data []byte) error {
-	type aux struct {
-		MaxNumberOfApples   int  `json:"maxNumberOfApples,omitempty"`
-		NumberOfLives       uint `json:"numberOfLives,omitempty"`
-		SnakeStartingLength int  `json:"snakeStartingLength,omitempty"`
+// configJSON is the on-disk representation of a Config.
+type configJSON struct {
+	MaxNumberOfApples   int                  `json:"maxNumberOfApples,omitempty"`
+	NumberOfLives       int                  `json:"numberOfLives,omitempty"`
+	SnakeStartingLength int                  `json:"snakeStartingLength,omitempty"`
+	Seed                int64                `json:"seed,omitempty"`
+	BoardWidth          int                  `json:"boardWidth,omitempty"`
+	BoardHeight         int                  `json:"boardHeight,omitempty"`
+	FillTerminal        bool                 `json:"fillTerminal,omitempty"`
+	WallMode            WallMode             `json:"wallMode,omitempty"`
+	AppleWeights        map[ui.AppleKind]int `json:"appleWeights,omitempty"`
+	Players             int                  `json:"players,omitempty"`
+	Bots                int                  `json:"bots,omitempty"`
+	BotDifficulty       Difficulty           `json:"botDifficulty,omitempty"`
+	ApplePlacement      Placement            `json:"applePlacement,omitempty"`
+}
+
+// fields returns pointers to the fields of the file format, by their keys.
+func (a *configJSON) fields() map[string]any {
+	return map[string]any{
+		"maxNumberOfApples":   &a.MaxNumberOfApples,
+		"numberOfLives":       &a.NumberOfLives,
+		"snakeStartingLength": &a.SnakeStartingLength,
+		"seed":                &a.Seed,
+		"boardWidth":          &a.BoardWidth,
+		"boardHeight":         &a.BoardHeight,
+		"fillTerminal":        &a.FillTerminal,
+		"wallMode":            &a.WallMode,
+		"appleWeights":        &a.AppleWeights,
+		"players":             &a.Players,
+		"bots":                &a.Bots,
+		"botDifficulty":       &a.BotDifficulty,
+		"applePlacement":      &a.ApplePlacement,
 	}
-	var a aux
-	if err := json.Unmarshal(data, &a); err != nil {
+}
+
+// UnmarshalJSON updates the configuration using the provided JSON data. Every field that
+// can't be read or isn't valid is reported as a FieldError, and unknown fields are rejected.
+// Whether the snakes and apples fit on the board is left to Validate, since flags and
+// environment variables may still change the configuration.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	var a configJSON
+	fields := a.fields()
+	var errs []error
+	read := make(map[string]bool, len(raw))
+	for _, key := range slices.Sorted(maps.Keys(raw)) {
+		field, ok := fields[key]
+		if !ok {
+			errs = append(errs, &FieldError{Path: key, Err: ErrUnknownField})
+			continue
+		}
+		if err := json.Unmarshal(raw[key], field); err == nil {
+			read[key] = true
+		} else {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				err = fmt.Errorf("expected %s, got %s", typeErr.Type, typeErr.Value)
+			}
+			errs = append(errs, &FieldError{Path: key, Err: err})
+		}
+	}
+	// zero is how these fields ask for their default, so it can't be given on purpose
+	zeros := map[string]bool{
+		"maxNumberOfApples":   a.MaxNumberOfApples == 0,
+		"numberOfLives":       a.NumberOfLives == 0,
+		"snakeStartingLength": a.SnakeStartingLength == 0,
+		"boardWidth":          a.BoardWidth == 0,
+		"boardHeight":         a.BoardHeight == 0,
+		"players":             a.Players == 0,
+	}
+	for _, key := range slices.Sorted(maps.Keys(zeros)) {
+		if read[key] && zeros[key] {
+			errs = append(errs, &FieldError{Path: key, Err: ErrZeroField})
+		}
+	}
+	if a.NumberOfLives < 0 {
+		errs = append(errs, &FieldError{Path: "numberOfLives", Err: errNegative})
+		a.NumberOfLives = 0
+	}
+
 	c.snakeStartingLength = a.SnakeStartingLength
-	c.numberOfLives = a.NumberOfLives
+	c.numberOfLives = uint(a.NumberOfLives)
 	c.maxNumberOfApples = a.MaxNumberOfApples
-	return nil
+	c.seed = a.Seed
+	c.boardWidth = a.BoardWidth
+	c.boardHeight = a.BoardHeight
+	c.fillTerminal = a.FillTerminal
+	c.wallMode = a.WallMode
+	c.appleWeights = a.AppleWeights
+	c.players = a.Players
+	c.bots = a.Bots
+	c.botDifficulty = a.BotDifficulty
+	c.applePlacement = a.ApplePlacement
+	return errors.Join(append(errs, c.fieldErrors()...)...)
+}
+
+// MarshalJSON encodes the configuration in the same format read by UnmarshalJSON.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(configJSON{
+		MaxNumberOfApples:   c.maxNumberOfApples,
+		NumberOfLives:       int(c.numberOfLives),
+		SnakeStartingLength: c.snakeStartingLength,
+		Seed:                c.seed,
+		BoardWidth:          c.boardWidth,
+		BoardHeight:         c.boardHeight,
+		FillTerminal:        c.fillTerminal,
+		WallMode:            c.wallMode,
+		AppleWeights:        c.appleWeights,
+		Players:             c.players,
+		Bots:                c.bots,
+		BotDifficulty:       c.botDifficulty,
+		ApplePlacement:      c.applePlacement,
+	})
 }
 
 // MaxNumberOfApples returns the configured maximum number of apples.
@@ -45,6 +204,11 @@ func (c *Config) MaxNumberOfApples() int {
 	return c.maxNumberOfApples
 }
 
+// SetMaxNumberOfApples overrides the configured maximum number of apples.
+func (c *Config) SetMaxNumberOfApples(apples int) {
+	c.maxNumberOfApples = apples
+}
+
 // NumberOfLives returns the configured initial number of lives.
 // If no value is configured, it returns the default value.
 func (c *Config) NumberOfLives() uint {
@@ -54,6 +218,11 @@ func (c *Config) NumberOfLives() uint {
 	return c.numberOfLives
 }
 
+// SetNumberOfLives overrides the configured initial number of lives.
+func (c *Config) SetNumberOfLives(lives uint) {
+	c.numberOfLives = lives
+}
+
 // SnakeStartingLength returns the configured initial length for the snake.
 // If no value is configured, it returns the default value.
 func (c *Config) SnakeStartingLength() int {
@@ -63,6 +232,305 @@ func (c *Config) SnakeStartingLength() int {
 	return c.snakeStartingLength
 }
 
+// SetSnakeStartingLength overrides the configured initial length for the snake.
+func (c *Config) SetSnakeStartingLength(length int) {
+	c.snakeStartingLength = length
+}
+
+// Seed returns the configured seed for the random number generator.
+// A value of zero means no seed is configured and every game is random.
+func (c *Config) Seed() int64 {
+	return c.seed
+}
+
+// SetSeed overrides the configured seed for the random number generator.
+func (c *Config) SetSeed(seed int64) {
+	c.seed = seed
+}
+
+// BoardWidth returns the configured width of the board, including its border.
+// If no value is configured, it returns the default value.
+func (c *Config) BoardWidth() int {
+	if c.boardWidth == 0 {
+		return DefaultBoardWidth
+	}
+	return c.boardWidth
+}
+
+// SetBoardWidth overrides the configured width of the board.
+func (c *Config) SetBoardWidth(width int) {
+	c.boardWidth = width
+}
+
+// BoardHeight returns the configured height of the board, including its border and HUD.
+// If no value is configured, it returns the default value.
+func (c *Config) BoardHeight() int {
+	if c.boardHeight == 0 {
+		return DefaultBoardHeight
+	}
+	return c.boardHeight
+}
+
+// SetBoardHeight overrides the configured height of the board.
+func (c *Config) SetBoardHeight(height int) {
+	c.boardHeight = height
+}
+
+// FillTerminal reports whether the board should grow to fill the terminal instead of
+// using the configured dimensions.
+func (c *Config) FillTerminal() bool {
+	return c.fillTerminal
+}
+
+// SetFillTerminal overrides whether the board grows to fill the terminal.
+func (c *Config) SetFillTerminal(fill bool) {
+	c.fillTerminal = fill
+}
+
+// WallMode returns what happens when the snake runs into a wall.
+// If no value is configured, walls are solid.
+func (c *Config) WallMode() WallMode {
+	return c.wallMode
+}
+
+// SetWallMode overrides what happens when the snake runs into a wall.
+func (c *Config) SetWallMode(mode WallMode) {
+	c.wallMode = mode
+}
+
+// AppleWeight returns the relative chance of an apple of the given kind being spawned.
+// Kinds without a configured weight use the default, so a weight of zero has to be set to
+// stop a kind from spawning.
+func (c *Config) AppleWeight(kind ui.AppleKind) int {
+	if w, ok := c.appleWeights[kind]; ok {
+		return w
+	}
+	return DefaultAppleWeights[kind]
+}
+
+// SetAppleWeight overrides the chance of an apple of the given kind being spawned. The
+// weights are copied first, so copies of the configuration don't share the change.
+func (c *Config) SetAppleWeight(kind ui.AppleKind, weight int) {
+	c.appleWeights = maps.Clone(c.appleWeights)
+	if c.appleWeights == nil {
+		c.appleWeights = make(map[ui.AppleKind]int, len(ui.AppleKinds))
+	}
+	c.appleWeights[kind] = weight
+}
+
+// Players returns the number of people playing on the same keyboard.
+// If no value is configured, it returns the default value.
+func (c *Config) Players() int {
+	if c.players == 0 {
+		return DefaultPlayers
+	}
+	return c.players
+}
+
+// SetPlayers overrides the configured number of players.
+func (c *Config) SetPlayers(players int) {
+	c.players = players
+}
+
+// Bots returns the number of computer-controlled snakes sharing the board with the players.
+func (c *Config) Bots() int {
+	return c.bots
+}
+
+// SetBots overrides the configured number of bots.
+func (c *Config) SetBots(bots int) {
+	c.bots = bots
+}
+
+// BotDifficulty returns how well the bots play.
+// If no value is configured, bots are easy.
+func (c *Config) BotDifficulty() Difficulty {
+	return c.botDifficulty
+}
+
+// SetBotDifficulty overrides how well the bots play.
+func (c *Config) SetBotDifficulty(difficulty Difficulty) {
+	c.botDifficulty = difficulty
+}
+
+// ApplePlacement returns how apples are placed on the board.
+// If no value is configured, apples can go on any free cell.
+func (c *Config) ApplePlacement() Placement {
+	return c.applePlacement
+}
+
+// SetApplePlacement overrides how apples are placed on the board.
+func (c *Config) SetApplePlacement(placement Placement) {
+	c.applePlacement = placement
+}
+
+// BoardSize returns the size of the board for a screen of the given size. The board
+// shrinks to fit smaller screens, but never below the minimum playable size.
+func (c *Config) BoardSize(screenWidth int, screenHeight int) (int, int) {
+	width, height := screenWidth, screenHeight
+	if !c.FillTerminal() {
+		width, height = min(width, c.BoardWidth()), min(height, c.BoardHeight())
+	}
+	return max(width, minWidth), max(height, minHeight)
+}
+
+// ValidateScreenSize checks that the configured board can be played on a screen of the
+// given size, which is when BoardSize won't have to shrink it to fit.
+func (c *Config) ValidateScreenSize(screenWidth int, screenHeight int) error {
+	if c.FillTerminal() {
+		if screenWidth < minWidth || screenHeight < minHeight {
+			return fmt.Errorf("terminal is %dx%d, but the board needs at least %dx%d",
+				screenWidth, screenHeight, minWidth, minHeight)
+		}
+		return nil
+	}
+	if c.BoardWidth() < minWidth || c.BoardHeight() < minHeight {
+		return fmt.Errorf("board is %dx%d, but must be at least %dx%d",
+			c.BoardWidth(), c.BoardHeight(), minWidth, minHeight)
+	}
+	if c.BoardWidth() > screenWidth || c.BoardHeight() > screenHeight {
+		return fmt.Errorf("board is %dx%d, but the terminal is only %dx%d",
+			c.BoardWidth(), c.BoardHeight(), screenWidth, screenHeight)
+	}
+	return nil
+}
+
+// Validate checks that every field of the configuration is in range, and that the snakes
+// and apples fit on the board, returning a FieldError for each field that isn't.
+func (c *Config) Validate() error {
+	return errors.Join(c.problems()...)
+}
+
+// problems returns the errors reported by Validate.
+func (c *Config) problems() []error {
+	return append(c.fieldErrors(), c.boardErrors()...)
+}
+
+// fieldErrors checks every field on its own.
+func (c *Config) fieldErrors() []error {
+	var errs []error
+	add := func(path string, err error) {
+		errs = append(errs, &FieldError{Path: path, Err: err})
+	}
+	if c.maxNumberOfApples < 0 {
+		add("maxNumberOfApples", errNegative)
+	}
+	if c.snakeStartingLength < 0 {
+		add("snakeStartingLength", errNegative)
+	}
+	if c.boardWidth != 0 && c.boardWidth < minWidth {
+		add("boardWidth", fmt.Errorf("must be at least %d", minWidth))
+	}
+	if c.boardHeight != 0 && c.boardHeight < minHeight {
+		add("boardHeight", fmt.Errorf("must be at least %d", minHeight))
+	}
+	if _, err := c.wallMode.MarshalText(); err != nil {
+		add("wallMode", err)
+	}
+	if c.players < 0 || c.players > MaxPlayers {
+		add("players", fmt.Errorf("must be between 1 and %d", MaxPlayers))
+	}
+	if c.bots < 0 || c.bots > MaxBots {
+		add("bots", fmt.Errorf("must be between 0 and %d", MaxBots))
+	}
+	if _, err := c.botDifficulty.MarshalText(); err != nil {
+		add("botDifficulty", err)
+	}
+	if _, err := c.applePlacement.MarshalText(); err != nil {
+		add("applePlacement", err)
+	}
+	total := 0
+	for _, kind := range ui.AppleKinds {
+		if w := c.AppleWeight(kind); w < 0 {
+			add("appleWeights."+kind.String(), errNegative)
+		} else {
+			total += w
+		}
+	}
+	if total == 0 {
+		add("appleWeights", errors.New("at least one kind of apple needs a weight above zero"))
+	}
+	return errs
+}
+
+// boardErrors checks that the snakes and apples fit on the configured board. A board that
+// fills the terminal is checked at its smallest size. Fields that fieldErrors rejects are
+// measured at their defaults instead, so they aren't reported twice.
+func (c *Config) boardErrors() []error {
+	cfg := *c
+	if cfg.boardWidth < minWidth {
+		cfg.boardWidth = 0
+	}
+	if cfg.boardHeight < minHeight {
+		cfg.boardHeight = 0
+	}
+	if cfg.players < 0 || cfg.players > MaxPlayers {
+		cfg.players = 0
+	}
+	if cfg.bots < 0 || cfg.bots > MaxBots {
+		cfg.bots = 0
+	}
+	cfg.snakeStartingLength = max(cfg.snakeStartingLength, 0)
+	cfg.maxNumberOfApples = max(cfg.maxNumberOfApples, 0)
+
+	width, height := cfg.BoardWidth(), cfg.BoardHeight()
+	if cfg.FillTerminal() {
+		width, height = minWidth, minHeight
+	}
+	b := &gameBoard{GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{}, width, height), cfg: &cfg}
+	b.addSnakes()
+
+	var errs []error
+	taken := make(map[ui.Position]bool)
+	fits := true
+	for _, s := range b.snakes() {
+		for _, pos := range s.Body {
+			if !b.IsInside(pos) || taken[pos] {
+				fits = false
+				continue
+			}
+			taken[pos] = true
+		}
+	}
+	if !fits {
+		err := fmt.Errorf("%d doesn't fit on a %dx%d board", cfg.SnakeStartingLength(), width, height)
+		if n := len(b.snakes()); n > 1 {
+			err = fmt.Errorf("%w with %d snakes", err, n)
+		}
+		errs = append(errs, &FieldError{Path: "snakeStartingLength", Err: err})
+	}
+	free := (b.Right()-b.Left()-1)*(b.Bottom()-b.Top()-1) - len(taken)
+	if cfg.MaxNumberOfApples() > free {
+		errs = append(errs, &FieldError{Path: "maxNumberOfApples", Err: fmt.Errorf(
+			"%d don't fit in the %d free cells of a %dx%d board", cfg.MaxNumberOfApples(), free, width, height)})
+	}
+	return errs
+}
+
+// ConfigPaths returns where the config file is looked for, in order: the snake directory of
+// the user's config directory, which is XDG_CONFIG_HOME/snake on Linux, next to the executable,
+// then the working directory.
+func ConfigPaths() []string {
+	var ret []string
+	if dir, err := os.UserConfigDir(); err == nil {
+		ret = append(ret, filepath.Join(dir, progressDir, ConfigFile))
+	}
+	if exe, err := os.Executable(); err == nil {
+		ret = append(ret, filepath.Join(filepath.Dir(exe), ConfigFile))
+	}
+	return append(ret, ConfigFile)
+}
+
+// FindConfig returns the first of the paths that holds a file, or "" if none of them do.
+func FindConfig(paths []string) string {
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
 // LoadConfig loads the game configuration from a file.
 func LoadConfig(filename string) (*Config, error) {
 	file, err := os.Open(filename)
@@ -75,3 +543,38 @@ func LoadConfig(filename string) (*Config, error) {
 	}
 	return &ret, nil
 }
+
+// CheckConfigFile reports every problem with a config file, as Validate would once it's
+// loaded, including problems with the board when some fields can't be read.
+func CheckConfigFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open: %w", err)
+	}
+	var cfg Config
+	var errs []error
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		} else {
+			errs = []error{err}
+		}
+	}
+	return errors.Join(append(errs, cfg.boardErrors()...)...)
+}
+
+// Save writes the configuration to a file in the format read by LoadConfig, creating its
+// directory if needed.
+func (c *Config) Save(filename string) error {
+	data, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err = os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
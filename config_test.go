@@ -3,6 +3,8 @@ package main
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"snake/ui"
 	"strings"
 	"testing"
 
@@ -65,6 +67,188 @@ func Test_Config(t *testing.T) {
 	})
 }
 
+func Test_ConfigMarshalJSON(t *testing.T) {
+	cfg := expectedConfig
+	cfg.seed = 42
+
+	data, err := json.Marshal(&cfg)
+	require.NoError(t, err)
+
+	var act Config
+	require.NoError(t, json.Unmarshal(data, &act))
+	require.Equal(t, cfg, act)
+}
+
+func Test_ConfigSeed(t *testing.T) {
+	t.Run("reads seed from json", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"seed": 42}`), &cfg))
+
+		require.Equal(t, int64(42), cfg.Seed())
+	})
+
+	t.Run("seed is zero when not defined", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(exampleConfig), &cfg))
+
+		require.Zero(t, cfg.Seed())
+	})
+
+	t.Run("seed can be overridden", func(t *testing.T) {
+		var cfg Config
+		cfg.SetSeed(7)
+
+		require.Equal(t, int64(7), cfg.Seed())
+	})
+}
+
+func Test_ConfigBoardSize(t *testing.T) {
+	t.Run("reads board dimensions from json", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"boardWidth": 50, "boardHeight": 25, "fillTerminal": true}`), &cfg))
+
+		require.Equal(t, 50, cfg.BoardWidth())
+		require.Equal(t, 25, cfg.BoardHeight())
+		require.True(t, cfg.FillTerminal())
+	})
+
+	t.Run("returns default board dimensions when not defined", func(t *testing.T) {
+		var cfg Config
+
+		require.Equal(t, DefaultBoardWidth, cfg.BoardWidth())
+		require.Equal(t, DefaultBoardHeight, cfg.BoardHeight())
+		require.False(t, cfg.FillTerminal())
+	})
+
+	t.Run("board uses configured size on large screens", func(t *testing.T) {
+		cfg := Config{boardWidth: 60, boardHeight: 30}
+
+		width, height := cfg.BoardSize(200, 100)
+
+		require.Equal(t, 60, width)
+		require.Equal(t, 30, height)
+	})
+
+	t.Run("board shrinks to fit smaller screens", func(t *testing.T) {
+		cfg := Config{boardWidth: 60, boardHeight: 30}
+
+		width, height := cfg.BoardSize(50, 25)
+
+		require.Equal(t, 50, width)
+		require.Equal(t, 25, height)
+	})
+
+	t.Run("board never shrinks below minimum", func(t *testing.T) {
+		cfg := Config{}
+
+		width, height := cfg.BoardSize(5, 5)
+
+		require.Equal(t, minWidth, width)
+		require.Equal(t, minHeight, height)
+	})
+
+	t.Run("board fills the terminal", func(t *testing.T) {
+		cfg := Config{fillTerminal: true}
+
+		width, height := cfg.BoardSize(200, 100)
+
+		require.Equal(t, 200, width)
+		require.Equal(t, 100, height)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			cfg           Config
+			width, height int
+			valid         bool
+		}{
+			{name: "default board fits", cfg: Config{}, width: DefaultBoardWidth, height: DefaultBoardHeight, valid: true},
+			{name: "default board on a small screen", cfg: Config{}, width: 30, height: 30},
+			{name: "configured board fits", cfg: Config{boardWidth: 60, boardHeight: 30}, width: 60, height: 30, valid: true},
+			{name: "configured board is too wide", cfg: Config{boardWidth: 60, boardHeight: 30}, width: 59, height: 30},
+			{name: "configured board is too tall", cfg: Config{boardWidth: 60, boardHeight: 30}, width: 60, height: 29},
+			{name: "configured board is below minimum", cfg: Config{boardWidth: minWidth - 1}, width: 100, height: 100},
+			{name: "filled terminal is large enough", cfg: Config{fillTerminal: true}, width: minWidth, height: minHeight, valid: true},
+			{name: "filled terminal is too small", cfg: Config{fillTerminal: true}, width: minWidth, height: minHeight - 1},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := tt.cfg.ValidateScreenSize(tt.width, tt.height)
+				if tt.valid {
+					require.NoError(t, err)
+					if !tt.cfg.FillTerminal() {
+						width, height := tt.cfg.BoardSize(tt.width, tt.height)
+						require.Equal(t, [2]int{tt.cfg.BoardWidth(), tt.cfg.BoardHeight()}, [2]int{width, height},
+							"a board that passes isn't shrunk")
+					}
+				} else {
+					require.Error(t, err)
+				}
+			})
+		}
+	})
+}
+
+func Test_ConfigWallMode(t *testing.T) {
+	for _, mode := range wallModes {
+		t.Run("reads "+mode.String()+" walls from json", func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, json.Unmarshal([]byte(`{"wallMode": "`+mode.String()+`"}`), &cfg))
+
+			require.Equal(t, mode, cfg.WallMode())
+		})
+	}
+
+	t.Run("rejects unknown wall modes", func(t *testing.T) {
+		var cfg Config
+		require.Error(t, json.Unmarshal([]byte(`{"wallMode": "bouncy"}`), &cfg))
+	})
+
+	t.Run("writes wall mode as text", func(t *testing.T) {
+		data, err := json.Marshal(&Config{wallMode: WrapWalls})
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"wallMode": "wrap"}`, string(data))
+	})
+}
+
+func Test_ConfigAppleWeights(t *testing.T) {
+	t.Run("returns default weights when not defined", func(t *testing.T) {
+		var cfg Config
+
+		for _, kind := range ui.AppleKinds {
+			require.Equal(t, DefaultAppleWeights[kind], cfg.AppleWeight(kind))
+		}
+	})
+
+	t.Run("reads weights from json, keeping defaults for missing kinds", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"appleWeights": {"golden": 20, "poison": 0}}`), &cfg))
+
+		require.Equal(t, 20, cfg.AppleWeight(ui.GoldenApple))
+		require.Equal(t, 0, cfg.AppleWeight(ui.PoisonApple))
+		require.Equal(t, DefaultAppleWeights[ui.NormalApple], cfg.AppleWeight(ui.NormalApple))
+	})
+
+	t.Run("rejects unknown kinds", func(t *testing.T) {
+		var cfg Config
+		require.Error(t, json.Unmarshal([]byte(`{"appleWeights": {"rotten": 1}}`), &cfg))
+	})
+
+	t.Run("rejects negative weights", func(t *testing.T) {
+		var cfg Config
+		require.Error(t, json.Unmarshal([]byte(`{"appleWeights": {"speed": -1}}`), &cfg))
+	})
+
+	t.Run("writes weights by kind name", func(t *testing.T) {
+		data, err := json.Marshal(&Config{appleWeights: map[ui.AppleKind]int{ui.TimedApple: 3}})
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"appleWeights": {"timed": 3}}`, string(data))
+	})
+}
+
 func Test_LoadConfigFromFile(t *testing.T) {
 	dir := t.TempDir()
 	file, err := os.CreateTemp(dir, "*.json")
@@ -77,3 +261,250 @@ func Test_LoadConfigFromFile(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, &expectedConfig, act)
 }
+
+func Test_ConfigPlayers(t *testing.T) {
+	t.Run("defaults to one player", func(t *testing.T) {
+		var cfg Config
+		require.Equal(t, DefaultPlayers, cfg.Players())
+	})
+
+	t.Run("reads players from json", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"players": 2}`), &cfg))
+
+		require.Equal(t, 2, cfg.Players())
+	})
+
+	t.Run("rejects more than two players", func(t *testing.T) {
+		var cfg Config
+		require.Error(t, json.Unmarshal([]byte(`{"players": 3}`), &cfg))
+	})
+}
+
+func Test_ConfigApplePlacement(t *testing.T) {
+	t.Run("defaults to uniform", func(t *testing.T) {
+		var cfg Config
+		require.Equal(t, UniformPlacement, cfg.ApplePlacement())
+	})
+
+	t.Run("reads the placement from json", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"applePlacement": "near-walls"}`), &cfg))
+
+		require.Equal(t, WallsPlacement, cfg.ApplePlacement())
+	})
+
+	t.Run("rejects unknown placements", func(t *testing.T) {
+		var cfg Config
+		require.Error(t, json.Unmarshal([]byte(`{"applePlacement": "anywhere"}`), &cfg))
+	})
+}
+
+func Test_ConfigSetters(t *testing.T) {
+	t.Run("set every field", func(t *testing.T) {
+		var cfg Config
+		cfg.SetMaxNumberOfApples(4)
+		cfg.SetNumberOfLives(5)
+		cfg.SetSnakeStartingLength(6)
+		cfg.SetBoardWidth(30)
+		cfg.SetBoardHeight(25)
+		cfg.SetFillTerminal(true)
+		cfg.SetWallMode(DeadlyWalls)
+		cfg.SetAppleWeight(ui.GoldenApple, 20)
+		cfg.SetBots(2)
+		cfg.SetBotDifficulty(HardBot)
+		cfg.SetApplePlacement(FarPlacement)
+
+		require.Equal(t, Config{
+			maxNumberOfApples:   4,
+			numberOfLives:       5,
+			snakeStartingLength: 6,
+			boardWidth:          30,
+			boardHeight:         25,
+			fillTerminal:        true,
+			wallMode:            DeadlyWalls,
+			appleWeights:        map[ui.AppleKind]int{ui.GoldenApple: 20},
+			bots:                2,
+			botDifficulty:       HardBot,
+			applePlacement:      FarPlacement,
+		}, cfg)
+	})
+
+	t.Run("setting a weight doesn't change copies", func(t *testing.T) {
+		var cfg Config
+		cfg.SetAppleWeight(ui.NormalApple, 10)
+		cp := cfg
+
+		cp.SetAppleWeight(ui.NormalApple, 50)
+
+		require.Equal(t, 10, cfg.AppleWeight(ui.NormalApple))
+		require.Equal(t, 50, cp.AppleWeight(ui.NormalApple))
+	})
+}
+
+func Test_SaveConfig(t *testing.T) {
+	t.Run("saved config loads back", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "snake", "config.json")
+		cfg := expectedConfig
+		cfg.SetWallMode(WrapWalls)
+		cfg.SetAppleWeight(ui.TimedApple, 0)
+
+		require.NoError(t, cfg.Save(file))
+		act, err := LoadConfig(file)
+
+		require.NoError(t, err)
+		require.Equal(t, &cfg, act)
+	})
+}
+
+func Test_ConfigValidate(t *testing.T) {
+	// fieldErrors unmarshals data and returns the path of every field error, in order
+	fieldErrors := func(t *testing.T, data string) []string {
+		var cfg Config
+		err := json.Unmarshal([]byte(data), &cfg)
+		require.Error(t, err)
+		var ret []string
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var fieldErr *FieldError
+			require.ErrorAs(t, e, &fieldErr)
+			ret = append(ret, fieldErr.Path)
+		}
+		return ret
+	}
+
+	t.Run("accepts the default configuration", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("reports every bad field with its path", func(t *testing.T) {
+		paths := fieldErrors(t, `{"maxNumberOfApples": -1, "numberOfLives": -2, "boardWidth": 10, "bots": 9, "appleWeights": {"golden": -5}}`)
+
+		require.Equal(t, []string{"numberOfLives", "maxNumberOfApples", "boardWidth", "bots", "appleWeights.golden"}, paths)
+	})
+
+	t.Run("leaves the board to be checked once the config is complete", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"snakeStartingLength": 30}`), &cfg))
+		require.Error(t, cfg.Validate())
+
+		cfg.SetBoardWidth(100)
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("checks a file's board even when other fields are bad", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), ConfigFile)
+		require.NoError(t, os.WriteFile(file, []byte(`{"players": "two", "boardWidth": 5, "snakeStartingLength": 50}`), 0o644))
+
+		err := CheckConfigFile(file)
+
+		var paths []string
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var fieldErr *FieldError
+			require.ErrorAs(t, e, &fieldErr)
+			paths = append(paths, fieldErr.Path)
+		}
+		require.Equal(t, []string{"players", "boardWidth", "snakeStartingLength"}, paths)
+	})
+
+	t.Run("reports snakes and apples that both don't fit", func(t *testing.T) {
+		cfg := Config{snakeStartingLength: 30, maxNumberOfApples: 2000}
+
+		err := cfg.Validate()
+
+		require.ErrorContains(t, err, "snakeStartingLength: ")
+		require.ErrorContains(t, err, "maxNumberOfApples: ")
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		var cfg Config
+		err := json.Unmarshal([]byte(`{"numberOfLifes": 5}`), &cfg)
+
+		require.ErrorIs(t, err, ErrUnknownField)
+		require.ErrorContains(t, err, "numberOfLifes")
+	})
+
+	t.Run("rejects zero where it means the default", func(t *testing.T) {
+		var cfg Config
+		err := json.Unmarshal([]byte(`{"snakeStartingLength": 0}`), &cfg)
+
+		require.ErrorIs(t, err, ErrZeroField)
+	})
+
+	t.Run("accepts zero apple weights and seed", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"seed": 0, "appleWeights": {"poison": 0}}`), &cfg))
+	})
+
+	t.Run("reports fields of the wrong type", func(t *testing.T) {
+		paths := fieldErrors(t, `{"players": "two"}`)
+
+		require.Equal(t, []string{"players"}, paths)
+	})
+
+	t.Run("rejects weights that never spawn an apple", func(t *testing.T) {
+		var cfg Config
+		for _, kind := range ui.AppleKinds {
+			cfg.SetAppleWeight(kind, 0)
+		}
+
+		require.ErrorContains(t, cfg.Validate(), "appleWeights: ")
+	})
+
+	t.Run("rejects snakes longer than the board", func(t *testing.T) {
+		cfg := Config{snakeStartingLength: DefaultBoardWidth}
+
+		require.ErrorContains(t, cfg.Validate(), "snakeStartingLength: ")
+	})
+
+	t.Run("rejects snakes that run into each other", func(t *testing.T) {
+		cfg := Config{snakeStartingLength: 8, boardWidth: minWidth, boardHeight: minHeight, players: 2}
+
+		require.ErrorContains(t, cfg.Validate(), "with 2 snakes")
+	})
+
+	t.Run("checks a board that fills the terminal at its smallest", func(t *testing.T) {
+		cfg := Config{snakeStartingLength: 15, fillTerminal: true}
+
+		require.ErrorContains(t, cfg.Validate(), "snakeStartingLength: ")
+	})
+
+	t.Run("rejects more apples than free cells", func(t *testing.T) {
+		cfg := Config{maxNumberOfApples: 400, boardWidth: minWidth, boardHeight: minHeight}
+
+		require.ErrorContains(t, cfg.Validate(), "maxNumberOfApples: ")
+	})
+}
+
+func Test_FindConfig(t *testing.T) {
+	t.Run("looks in the user's config directory first", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", dir)
+
+		paths := ConfigPaths()
+
+		require.Equal(t, filepath.Join(dir, "snake", ConfigFile), paths[0])
+		require.Equal(t, ConfigFile, paths[len(paths)-1])
+	})
+
+	t.Run("returns the first path that exists", func(t *testing.T) {
+		dir := t.TempDir()
+		first, second := filepath.Join(dir, "a", ConfigFile), filepath.Join(dir, "b", ConfigFile)
+		require.NoError(t, (&Config{}).Save(second))
+
+		require.Equal(t, second, FindConfig([]string{first, second}))
+
+		require.NoError(t, (&Config{}).Save(first))
+		require.Equal(t, first, FindConfig([]string{first, second}))
+	})
+
+	t.Run("skips directories", func(t *testing.T) {
+		dir := t.TempDir()
+
+		require.Empty(t, FindConfig([]string{dir}))
+	})
+
+	t.Run("returns nothing when no file exists", func(t *testing.T) {
+		require.Empty(t, FindConfig([]string{filepath.Join(t.TempDir(), ConfigFile)}))
+	})
+}
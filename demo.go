@@ -0,0 +1,122 @@
+package main
+
+import (
+	"slices"
+	"snake/grid"
+	"snake/ui"
+	"time"
+)
+
+const (
+	// DemoText is shown in the HUD while the demo is playing.
+	DemoText = "demo"
+	// shortcutMargin is how far a shortcut has to stay clear of the tail, leaving room for the
+	// snake to grow while it catches up with the apple.
+	shortcutMargin = 4
+)
+
+// pilot steers the first player's snake in place of the player.
+type pilot interface {
+	Update(board *gameBoard, delta time.Duration)
+}
+
+// hamiltonian plays perfectly by following a cycle that visits every cell of the board, so the
+// snake can never trap itself. While the snake is short it cuts across the cycle towards the
+// next apple, as long as the shortcut doesn't take it past its own tail.
+type hamiltonian struct {
+	snake *snake
+	cycle []ui.Position
+	// order is where each cell comes in the cycle.
+	order map[ui.Position]int
+}
+
+// newDemoGame returns a game that plays itself, following a hamiltonian cycle until the snake
+// fills the board. The board is narrowed by a column if that's needed for it to have a cycle.
+func newDemoGame(cfg Config, width int, height int) *game {
+	cfg.players, cfg.bots = 0, 0
+	g := newSnakeGame(&cfg, width, height)
+	if _, err := g.gameBoard.grid().HamiltonianCycle(); err != nil {
+		cfg.boardWidth, cfg.fillTerminal = g.gameBoard.Width()-1, false
+		g.gameBoard.Resize(width, height)
+	}
+	h := &hamiltonian{snake: g.gameBoard.snake}
+	g.gameBoard.demo, g.gameBoard.autopilot = h, h
+	g.gameBoard.SetMode(DemoText)
+	g.currentState = g.menu()
+	return g
+}
+
+// Update picks the snake's next move, if it's about to move.
+func (h *hamiltonian) Update(board *gameBoard, delta time.Duration) {
+	if h.snake.moveTimer-delta > 0 {
+		return
+	}
+	h.snake.Notify(moveEvents[h.choose(board)])
+}
+
+// place works out the cycle for the board and lays the snake along the start of it.
+func (h *hamiltonian) place(board *gameBoard) {
+	cycle, err := board.grid().HamiltonianCycle()
+	if err != nil {
+		h.cycle, h.order = nil, nil
+		return
+	}
+	h.cycle = cycle
+	h.order = make(map[ui.Position]int, len(cycle))
+	for i, c := range cycle {
+		h.order[c] = i
+	}
+	n := min(len(h.snake.Body), len(cycle)-1)
+	h.snake.Body = slices.Clone(cycle[:n])
+	h.snake.dir = towards(board.grid(), cycle[n-1], cycle[n], h.snake.dir)
+}
+
+// choose returns the direction the snake should move in next. Without a cycle, which happens
+// when the board is resized to one that has none, it plays like the hardest bot.
+func (h *hamiltonian) choose(board *gameBoard) direction {
+	head, tail := h.snake.head(), h.snake.Body[0]
+	if _, ok := h.order[head]; !ok || len(h.cycle) != board.cellCount() {
+		h.cycle, h.order = nil, nil
+		return newBot(h.snake, HardBot).choose(board)
+	}
+
+	g := board.grid()
+	n := len(h.cycle)
+	ahead := func(p ui.Position) int {
+		return (h.order[p] - h.order[head] + n) % n
+	}
+	best := towards(g, head, h.cycle[(h.order[head]+1)%n], h.snake.dir)
+	// shortcuts get riskier as the snake grows, so it sticks to the cycle once it fills half
+	// the board
+	if len(h.snake.Body)*2 >= n {
+		return best
+	}
+	limit := ahead(tail) - shortcutMargin
+	board.apples.ForEach(func(a *apple) {
+		if wanted(a) {
+			limit = min(limit, ahead(a.Pos))
+		}
+	})
+	bestAhead := 1
+	for _, d := range directions {
+		p := step(g, head, d)
+		if d.isOpposite(h.snake.dir) || g.Blocked(p) {
+			continue
+		}
+		if a := ahead(p); a > bestAhead && a <= limit {
+			best, bestAhead = d, a
+		}
+	}
+	return best
+}
+
+// towards returns the direction from one cell to its neighbour, or fallback if they aren't
+// neighbours.
+func towards(g *grid.Grid, from ui.Position, to ui.Position, fallback direction) direction {
+	for _, d := range directions {
+		if step(g, from, d) == to {
+			return d
+		}
+	}
+	return fallback
+}
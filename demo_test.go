@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Demo(t *testing.T) {
+	t.Run("starts playing straight away", func(t *testing.T) {
+		g := newDemoGame(Config{}, 30, 20)
+
+		require.IsType(t, new(playingState), g.currentState)
+		require.Equal(t, DemoText, g.gameBoard.ModeBox().Text())
+		require.Nil(t, g.recording)
+	})
+
+	t.Run("narrows boards without a cycle", func(t *testing.T) {
+		g := newDemoGame(Config{}, 31, 21)
+
+		_, err := g.gameBoard.grid().HamiltonianCycle()
+
+		require.NoError(t, err)
+		require.Equal(t, 30, g.gameBoard.Width())
+	})
+
+	t.Run("lays the snake along the cycle", func(t *testing.T) {
+		g := newDemoGame(Config{}, 30, 20)
+		h := g.gameBoard.demo
+
+		require.Equal(t, h.cycle[:DefaultStartingLength], g.gameBoard.snake.Body)
+	})
+
+	t.Run("ignores the player's moves", func(t *testing.T) {
+		g := newDemoGame(Config{}, 30, 20)
+		dir := g.gameBoard.snake.dir
+
+		g.Handle(keyPress(tcell.KeyDown, 0))
+		g.Handle(keyPress(tcell.KeyRune, 'p'))
+
+		require.Equal(t, dir, g.gameBoard.snake.dir)
+		require.Equal(t, g.gameBoard.demo, g.gameBoard.autopilot)
+	})
+
+	t.Run("fills the board without losing a life", func(t *testing.T) {
+		cfg := normalApplesConfig()
+		cfg.snakeStartingLength = 1
+		g := newDemoGame(*cfg, minWidth, minHeight)
+
+		for range 60 * 60 * 30 {
+			g.Update(TickDuration)
+			if _, ok := g.currentState.(*boardClearedState); ok {
+				break
+			}
+		}
+
+		require.IsType(t, new(boardClearedState), g.currentState)
+		assert.Equal(t, DefaultNumberOfLives, g.remainingLives)
+		assert.Equal(t, g.gameBoard.cellCount(), g.gameBoard.occupied().snakeCells)
+	})
+
+	t.Run("plays on without a cycle", func(t *testing.T) {
+		g := newDemoGame(Config{}, 30, 20)
+		g.gameBoard.demo.cycle = g.gameBoard.demo.cycle[1:]
+
+		g.gameBoard.demo.choose(g.gameBoard)
+
+		require.Nil(t, g.gameBoard.demo.cycle)
+	})
+}
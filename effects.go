@@ -0,0 +1,103 @@
+package main
+
+import (
+	"slices"
+	"snake/ui"
+	"time"
+)
+
+const (
+	slowMotionDuration = 5 * time.Second
+	// slowMotionFactor is how much slow-motion stretches the time between moves.
+	slowMotionFactor = 2.0
+	ghostDuration    = 5 * time.Second
+	shieldDuration   = 15 * time.Second
+)
+
+// powerUpDuration returns how long the effect of a power-up or a speed apple lasts, or zero
+// for a kind of effect it doesn't know.
+func powerUpDuration(kind ui.EffectKind) time.Duration {
+	switch kind {
+	case ui.SlowMotionEffect:
+		return slowMotionDuration
+	case ui.GhostEffect:
+		return ghostDuration
+	case ui.ShieldEffect:
+		return shieldDuration
+	case ui.SpeedEffect:
+		return speedAppleDuration
+	default:
+		return 0
+	}
+}
+
+// effect is a temporary change to how the snake behaves.
+type effect struct {
+	kind      ui.EffectKind
+	remaining time.Duration
+	// duration is the time the effect had when it was last gained, used to show how much
+	// is left.
+	duration time.Duration
+}
+
+// effects are the effects active on the snake, in the order they were gained. Different
+// effects stack, so slow-motion and a speed apple cancel each other out. Gaining an effect
+// that's already active adds to its remaining time instead, which means a shield still
+// only absorbs a single crash.
+type effects []effect
+
+func (e *effects) add(kind ui.EffectKind, d time.Duration) {
+	if i := e.index(kind); i >= 0 {
+		(*e)[i].remaining += d
+		(*e)[i].duration = (*e)[i].remaining
+		return
+	}
+	*e = append(*e, effect{kind: kind, remaining: d, duration: d})
+}
+
+// update counts down every effect, removing the ones that expire.
+func (e *effects) update(delta time.Duration) {
+	for i := range *e {
+		(*e)[i].remaining -= delta
+	}
+	*e = slices.DeleteFunc(*e, func(ef effect) bool { return ef.remaining <= 0 })
+}
+
+func (e effects) active(kind ui.EffectKind) bool {
+	return e.index(kind) >= 0
+}
+
+// consume removes an effect, reporting whether it was active.
+func (e *effects) consume(kind ui.EffectKind) bool {
+	i := e.index(kind)
+	if i < 0 {
+		return false
+	}
+	*e = slices.Delete(*e, i, i+1)
+	return true
+}
+
+func (e effects) index(kind ui.EffectKind) int {
+	return slices.IndexFunc(e, func(ef effect) bool { return ef.kind == kind })
+}
+
+// moveDelayFactor returns how much the active effects change the time between moves.
+func (e effects) moveDelayFactor() float64 {
+	ret := 1.0
+	if e.active(ui.SlowMotionEffect) {
+		ret *= slowMotionFactor
+	}
+	if e.active(ui.SpeedEffect) {
+		ret *= speedAppleFactor
+	}
+	return ret
+}
+
+// statuses describes the active effects for the HUD.
+func (e effects) statuses() []ui.EffectStatus {
+	ret := make([]ui.EffectStatus, len(e))
+	for i, ef := range e {
+		ret[i] = ui.EffectStatus{Kind: ef.kind, Remaining: float64(ef.remaining) / float64(ef.duration)}
+	}
+	return ret
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"snake/ui"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Effects(t *testing.T) {
+	t.Run("effects expire after their duration", func(t *testing.T) {
+		var e effects
+		e.add(ui.GhostEffect, time.Second)
+
+		e.update(time.Second - time.Millisecond)
+		require.True(t, e.active(ui.GhostEffect))
+
+		e.update(time.Millisecond)
+		require.False(t, e.active(ui.GhostEffect))
+		require.Empty(t, e)
+	})
+
+	t.Run("gaining an active effect adds to its time", func(t *testing.T) {
+		var e effects
+		e.add(ui.GhostEffect, time.Second)
+		e.update(time.Second / 2)
+
+		e.add(ui.GhostEffect, time.Second)
+
+		require.Len(t, e, 1)
+		require.Equal(t, 1500*time.Millisecond, e[0].remaining)
+		e.update(time.Second)
+		require.True(t, e.active(ui.GhostEffect))
+	})
+
+	t.Run("different effects are active together", func(t *testing.T) {
+		var e effects
+		e.add(ui.GhostEffect, time.Second)
+		e.add(ui.ShieldEffect, 2*time.Second)
+
+		e.update(time.Second)
+
+		require.False(t, e.active(ui.GhostEffect))
+		require.True(t, e.active(ui.ShieldEffect))
+	})
+
+	t.Run("consuming removes the effect", func(t *testing.T) {
+		var e effects
+		e.add(ui.ShieldEffect, time.Second)
+		e.add(ui.ShieldEffect, time.Second)
+
+		require.True(t, e.consume(ui.ShieldEffect))
+		require.False(t, e.consume(ui.ShieldEffect))
+	})
+
+	t.Run("move delay factors stack", func(t *testing.T) {
+		var e effects
+		require.Equal(t, 1.0, e.moveDelayFactor())
+
+		e.add(ui.SlowMotionEffect, time.Second)
+		require.Equal(t, slowMotionFactor, e.moveDelayFactor())
+
+		e.add(ui.SpeedEffect, time.Second)
+		require.Equal(t, slowMotionFactor*speedAppleFactor, e.moveDelayFactor())
+	})
+
+	t.Run("statuses show the fraction left", func(t *testing.T) {
+		var e effects
+		e.add(ui.GhostEffect, time.Second)
+		e.add(ui.ShieldEffect, 2*time.Second)
+
+		e.update(time.Second / 2)
+
+		require.Equal(t, []ui.EffectStatus{
+			{Kind: ui.GhostEffect, Remaining: 0.5},
+			{Kind: ui.ShieldEffect, Remaining: 0.75},
+		}, e.statuses())
+	})
+	t.Run("every kind of effect has its own duration", func(t *testing.T) {
+		require.Equal(t, slowMotionDuration, powerUpDuration(ui.SlowMotionEffect))
+		require.Equal(t, ghostDuration, powerUpDuration(ui.GhostEffect))
+		require.Equal(t, shieldDuration, powerUpDuration(ui.ShieldEffect))
+		require.Equal(t, speedAppleDuration, powerUpDuration(ui.SpeedEffect))
+		require.Zero(t, powerUpDuration(ui.EffectKind(-1)))
+	})
+}
@@ -13,8 +13,38 @@ const (
 	PauseGame
 	ExitGame
 	StartGame
+	ResizeScreen
+	// PlayerTwoMoveUp and the other PlayerTwo events are the moves of the second player in a
+	// two-player game.
+	PlayerTwoMoveUp
+	PlayerTwoMoveDown
+	PlayerTwoMoveLeft
+	PlayerTwoMoveRight
+	// ToggleAutopilot hands control of the first player's snake to the computer and back.
+	ToggleAutopilot
+	// ToggleHint shows or hides the path the autopilot would take.
+	ToggleHint
+	// Back leaves whatever is open, such as a submenu.
+	Back
 )
 
+// forPlayer splits an event into the player it's meant for and the event itself, so that
+// every snake can listen for the same moves.
+func forPlayer(event Event) (int, Event) {
+	switch event {
+	case PlayerTwoMoveUp:
+		return secondPlayer, MoveUp
+	case PlayerTwoMoveDown:
+		return secondPlayer, MoveDown
+	case PlayerTwoMoveLeft:
+		return secondPlayer, MoveLeft
+	case PlayerTwoMoveRight:
+		return secondPlayer, MoveRight
+	default:
+		return firstPlayer, event
+	}
+}
+
 type EventListener interface {
 	Notify(event Event)
 }
@@ -27,15 +57,18 @@ func (e EventListeners) Notify(event Event) {
 	}
 }
 
-var eventMap = &EventMap{}
-
+// EventMap converts input into Events. With two players, WASD moves the first player's snake
+// and the arrow keys move the second's, otherwise both move the only snake.
 type EventMap struct {
+	twoPlayer bool
 }
 
 func (e *EventMap) Get(event tcell.Event) Event {
 	switch ev := event.(type) {
 	case *tcell.EventKey:
 		return e.GetEventFromKey(ev)
+	case *tcell.EventResize:
+		return ResizeScreen
 	default:
 		return Unknown
 	}
@@ -55,20 +88,33 @@ func (e *EventMap) GetEventFromKey(ev *tcell.EventKey) Event {
 			return MoveRight
 		case ' ':
 			return PauseGame
+		case 'P', 'p':
+			return ToggleAutopilot
+		case 'H', 'h':
+			return ToggleHint
 		}
-		return MoveUp
+		return Unknown
 	case ev.Key() == tcell.KeyUp:
-		return MoveUp
+		return e.arrow(MoveUp, PlayerTwoMoveUp)
 	case ev.Key() == tcell.KeyDown:
-		return MoveDown
+		return e.arrow(MoveDown, PlayerTwoMoveDown)
 	case ev.Key() == tcell.KeyRight:
-		return MoveRight
+		return e.arrow(MoveRight, PlayerTwoMoveRight)
 	case ev.Key() == tcell.KeyLeft:
-		return MoveLeft
+		return e.arrow(MoveLeft, PlayerTwoMoveLeft)
 	case ev.Key() == tcell.KeyCtrlC:
 		return ExitGame
 	case ev.Key() == tcell.KeyEnter:
 		return StartGame
+	case ev.Key() == tcell.KeyEscape:
+		return Back
 	}
 	return Unknown
 }
+
+func (e *EventMap) arrow(onePlayer Event, twoPlayer Event) Event {
+	if e.twoPlayer {
+		return twoPlayer
+	}
+	return onePlayer
+}
@@ -45,4 +45,60 @@ func Test_EventMappings(t *testing.T) {
 	t.Run("exit event", func(t *testing.T) {
 		require.Equal(t, ExitGame, eventMap.Get(tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModNone)))
 	})
+
+	t.Run("resize event", func(t *testing.T) {
+		require.Equal(t, ResizeScreen, eventMap.Get(tcell.NewEventResize(10, 10)))
+	})
+
+	t.Run("back event", func(t *testing.T) {
+		require.Equal(t, Back, eventMap.Get(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)))
+	})
+
+	t.Run("other letters are unknown", func(t *testing.T) {
+		require.Equal(t, Unknown, eventMap.Get(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)))
+		require.Equal(t, Unknown, eventMap.Get(tcell.NewEventKey(tcell.KeyRune, '1', tcell.ModNone)))
+	})
+}
+
+func Test_TwoPlayerEventMappings(t *testing.T) {
+	eventMap := EventMap{twoPlayer: true}
+
+	t.Run("wasd moves player one", func(t *testing.T) {
+		require.Equal(t, MoveUp, eventMap.Get(tcell.NewEventKey(tcell.KeyRune, 'w', tcell.ModNone)))
+		require.Equal(t, MoveDown, eventMap.Get(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone)))
+		require.Equal(t, MoveRight, eventMap.Get(tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone)))
+		require.Equal(t, MoveLeft, eventMap.Get(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone)))
+	})
+
+	t.Run("arrow keys move player two", func(t *testing.T) {
+		require.Equal(t, PlayerTwoMoveUp, eventMap.Get(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)))
+		require.Equal(t, PlayerTwoMoveDown, eventMap.Get(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)))
+		require.Equal(t, PlayerTwoMoveRight, eventMap.Get(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone)))
+		require.Equal(t, PlayerTwoMoveLeft, eventMap.Get(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone)))
+	})
+
+	t.Run("shared keys are unchanged", func(t *testing.T) {
+		require.Equal(t, PauseGame, eventMap.Get(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)))
+		require.Equal(t, ExitGame, eventMap.Get(tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModNone)))
+	})
+}
+
+func Test_ForPlayer(t *testing.T) {
+	tests := []struct {
+		event  Event
+		player int
+		want   Event
+	}{
+		{MoveUp, firstPlayer, MoveUp},
+		{MoveLeft, firstPlayer, MoveLeft},
+		{PlayerTwoMoveUp, secondPlayer, MoveUp},
+		{PlayerTwoMoveDown, secondPlayer, MoveDown},
+		{PlayerTwoMoveLeft, secondPlayer, MoveLeft},
+		{PlayerTwoMoveRight, secondPlayer, MoveRight},
+	}
+	for _, tt := range tests {
+		player, event := forPlayer(tt.event)
+		require.Equal(t, tt.player, player)
+		require.Equal(t, tt.want, event)
+	}
 }
@@ -4,29 +4,115 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"snake/ui"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
-// maxWidth and maxHeight are zero-based numbers
-const maxWidth = 39
-const maxHeight = maxWidth
+// minWidth and minHeight are the smallest board that fits the HUD and leaves room to play.
+const minWidth = 20
+const minHeight = 15
 const pointsPerApple uint = 100
 
-type game struct {
-	*ui.Manager
-	cfg            *Config
-	gameBoard      *gameBoard
+// maxGeneratedSeed bounds the seeds picked for unseeded games, keeping them short enough to
+// be read off the game over screen.
+const maxGeneratedSeed = 1_000_000
+
+const gameBoardViewName = "GameBoard"
+
+const (
+	firstPlayer = iota
+	secondPlayer
+	// firstBot is the player index of the first bot, with each further bot numbered after it.
+	firstBot
+)
+
+// stats is how a player is doing in the current game.
+type stats struct {
 	score          uint
+	applesEaten    uint
 	remainingLives uint
+}
+
+const (
+	TicksPerSecond  = 60
+	TickDuration    = time.Second / TicksPerSecond
+	FramesPerSecond = 60
+	FrameDuration   = time.Second / FramesPerSecond
+	// MaxFrameDelta caps how much time a single frame can feed into the simulation, so a stalled
+	// terminal doesn't cause a burst of catch-up ticks.
+	MaxFrameDelta = time.Millisecond * 250
+)
+
+type game struct {
+	*ui.Manager
+	cfg       *Config
+	gameBoard *gameBoard
+	// stats are the first player's, who is the only player unless there are two.
+	stats
+	playerTwoStats stats
+	botStats       []stats
+	playTime       time.Duration
+	seed           int64
+	ticks          uint64
+	recording      *Replay
+	campaign       *campaignRun
+	highScores     *scoreTable
+	mainMenu       *MainMenu
+	settings       *settingsScreen
 	finished       bool
 	currentState   state
+	// screenWidth and screenHeight are the size of the terminal the game is played in.
+	screenWidth  int
+	screenHeight int
 }
 
 func (g *game) keyHandler(key *tcell.EventKey) {
-	switch event := eventMap.Get(key); event {
+	event := g.gameBoard.keys.Get(key)
+	if entry, ok := g.currentState.(textEntryState); ok {
+		// letters typed into the field mustn't steer, so only escape, which cancels the entry,
+		// and ctrl-c are handled here
+		switch {
+		case key.Key() == tcell.KeyEscape:
+			entry.cancel(g)
+		case event == ExitGame:
+			g.finished = true
+		}
+		return
+	}
+	if g.TooSmall() && event != ExitGame {
+		return
+	}
+	if g.gameBoard.demo != nil && event != ExitGame && event != PauseGame {
+		return
+	}
+	if g.recording != nil && g.inGame() {
+		g.recording.record(g.ticks, event)
+	}
+	g.handleEvent(event)
+}
+
+func (g *game) resizeHandler(width int, height int) {
+	g.screenWidth, g.screenHeight = width, height
+	if g.recording != nil && g.inGame() {
+		g.recording.recordResize(g.ticks, width, height)
+	}
+	if width < g.gameBoard.Width() || height < g.gameBoard.Height() {
+		g.pause()
+	}
+}
+
+// pause pauses the current game, if one is being played.
+func (g *game) pause() {
+	if p, ok := g.currentState.(*playingState); ok {
+		g.currentState = &pausedState{currentGame: p}
+	}
+}
+
+func (g *game) handleEvent(event Event) {
+	switch event {
 	case ExitGame:
 		g.finished = true
 	default:
@@ -35,39 +121,133 @@ func (g *game) keyHandler(key *tcell.EventKey) {
 }
 
 func (g *game) Update(delta time.Duration) {
+	if g.TooSmall() {
+		return
+	}
 	g.currentState.update(g, delta)
+	g.ticks += 1
 }
 
 func (g *game) Finished() bool {
 	return g.finished
 }
 
+// menu returns the state the game starts in and returns to after it's over.
+func (g *game) menu() state {
+	if g.gameBoard.demo != nil {
+		// the demo starts over straight away, and isn't recorded since replays can't steer
+		g.reset()
+		g.recording = nil
+		_ = g.SwitchView(gameBoardViewName)
+		return &playingState{board: g.gameBoard}
+	}
+	if g.campaign != nil {
+		g.showLevelSelect()
+		return new(levelSelectState)
+	}
+	_ = g.SwitchView(mainMenuViewName)
+	return new(menuState)
+}
+
+// inGame reports whether a game is being played or is paused, which is when it's recorded.
+// Whatever happens after the game, such as on the menus, isn't part of the replay.
+func (g *game) inGame() bool {
+	switch g.currentState.(type) {
+	case *playingState, *pausedState:
+		return true
+	}
+	return false
+}
+
+// statsFor returns the stats of the player controlling a snake.
+func (g *game) statsFor(s *snake) *stats {
+	switch {
+	case s.player == secondPlayer:
+		return &g.playerTwoStats
+	case s.player >= firstBot:
+		if i := s.player - firstBot; i >= len(g.botStats) {
+			g.botStats = append(g.botStats, make([]stats, i+1-len(g.botStats))...)
+		}
+		return &g.botStats[s.player-firstBot]
+	}
+	return &g.stats
+}
+
+func (g *game) twoPlayer() bool {
+	return g.gameBoard.playerTwo() != nil
+}
+
+// gameOver reports whether the game has ended, which is when any player runs out of lives.
 func (g *game) gameOver() bool {
-	return g.remainingLives == 0
+	return g.remainingLives == 0 || (g.twoPlayer() && g.playerTwoStats.remainingLives == 0)
+}
+
+// winner returns who won a two-player game: the player with lives left, or the higher
+// score when both players ran out of lives on the same tick.
+func (g *game) winner() string {
+	one, two := g.stats, g.playerTwoStats
+	switch {
+	case one.remainingLives > 0 && two.remainingLives == 0:
+		return PlayerOneWinsText
+	case two.remainingLives > 0 && one.remainingLives == 0:
+		return PlayerTwoWinsText
+	case one.score > two.score:
+		return PlayerOneWinsText
+	case two.score > one.score:
+		return PlayerTwoWinsText
+	}
+	return DrawText
 }
 
 func (g *game) reset() {
-	g.score = 0
-	g.remainingLives = g.cfg.NumberOfLives()
-	g.gameBoard.reset()
+	g.stats = stats{remainingLives: g.cfg.NumberOfLives()}
+	g.playerTwoStats = stats{remainingLives: g.cfg.NumberOfLives()}
+	g.botStats = nil
+	g.playTime = 0
+	g.seed = g.cfg.Seed()
+	if g.seed == 0 {
+		g.seed = rand.Int63n(maxGeneratedSeed) + 1
+	}
+	g.gameBoard.reset(g.seed)
+	g.ticks = 0
+	g.recording = newReplay(g)
 }
 
-func newSnakeGame(cfg *Config, width int, height int) *game {
-	b := newGameBoard(ui.Position{X: 0, Y: 0}, min(width, maxWidth), min(height, maxHeight), cfg)
+func newSnakeGame(cfg *Config, screenWidth int, screenHeight int) *game {
+	width, height := cfg.BoardSize(screenWidth, screenHeight)
+	b := newGameBoard(ui.Position{X: 0, Y: 0}, width, height, cfg)
 	mgr := ui.NewManager()
-	mgr.AddView("GameBoard", b)
+	mgr.AddView(gameBoardViewName, b)
+	mgr.SetMinimumSize(minWidth, minHeight)
 
 	ret := game{
 		Manager:        mgr,
 		cfg:            cfg,
 		gameBoard:      b,
-		remainingLives: cfg.NumberOfLives(),
+		stats:          stats{remainingLives: cfg.NumberOfLives()},
+		playerTwoStats: stats{remainingLives: cfg.NumberOfLives()},
 		currentState:   new(menuState),
+		screenWidth:    screenWidth,
+		screenHeight:   screenHeight,
 	}
+	ret.mainMenu = newMainMenu(&ret)
+	mgr.AddView(mainMenuViewName, ret.mainMenu)
+	_ = mgr.SwitchView(mainMenuViewName)
 	mgr.SetKeyEventCallback(ret.keyHandler)
+	mgr.SetResizeEventCallback(ret.resizeHandler)
 	return &ret
 }
 
+// setLevel lays the board out as the given level. The screen has to fit the whole level,
+// since the board no longer shrinks to fit the screen.
+func (g *game) setLevel(level *Level) error {
+	if err := g.gameBoard.setLevel(level); err != nil {
+		return err
+	}
+	g.SetMinimumSize(g.gameBoard.Width(), g.gameBoard.Height())
+	return nil
+}
+
 type Game interface {
 	ui.EventHandler
 	Update(delta time.Duration)
@@ -75,10 +255,10 @@ type Game interface {
 	Finished() bool
 }
 
-func RunGame(game Game, scrn tcell.Screen) (err error) {
-	const FramesPerSecond = 60
-	const FrameDuration = time.Second / FramesPerSecond
-
+// RunGame drives the game until it reports that it's finished. The simulation is advanced in
+// fixed steps of TickDuration, independent of how often frames are drawn, so game speed doesn't
+// depend on terminal load.
+func RunGame(game Game, scrn tcell.Screen, clock Clock) (err error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	eventQueue := runEventPoller(ctx, scrn)
 	defer func() {
@@ -88,24 +268,27 @@ func RunGame(game Game, scrn tcell.Screen) (err error) {
 		}
 	}()
 
-	now := time.Now()
-	var delta time.Duration
+	var accumulator time.Duration
+	prev := clock.Now()
 	for !game.Finished() {
-		next := time.Now()
-		delta = next.Sub(now)
-		now = next
+		frameStart := clock.Now()
+		accumulator += min(frameStart.Sub(prev), MaxFrameDelta)
+		prev = frameStart
 
 		select {
 		case ev := <-eventQueue:
 			game.Handle(ev)
 		default:
 		}
+		for accumulator >= TickDuration {
+			game.Update(TickDuration)
+			accumulator -= TickDuration
+		}
 		scrn.Clear()
-		game.Update(delta)
 		game.Draw(scrn)
 		scrn.Show()
 
-		time.Sleep(FrameDuration - delta)
+		clock.Sleep(FrameDuration - clock.Now().Sub(frameStart))
 	}
 	return nil
 }
@@ -1,29 +1,158 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
 	"snake/ui"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// ErrSinglePlayerOnly is returned when a level is set on a board shared with other snakes.
+var ErrSinglePlayerOnly = errors.New("levels are single player only")
+
 const livesFormat = "Lives: %d"
 const scoreFormat = "Score: %d"
+const twoPlayerLivesFormat = "Lives: %d | %d"
+const twoPlayerScoreFormat = "Score: %d | %d"
 
 type gameBoard struct {
 	*ui.GameBoardRenderer
-	snake  *snake
-	apples apples
+	snake *snake
+	// others are the snakes sharing the board with the first player's.
+	others []*snake
+	bots   []*bot
+	// autopilot steers the first player's snake while it's turned on.
+	autopilot pilot
+	// demo is set when the game plays itself, which leaves the player unable to steer.
+	demo     *hamiltonian
+	hint     *ui.TrailRenderer
+	apples   apples
+	powerUp  powerUp
+	rng      *rand.Rand
+	cfg      *Config
+	wallMode WallMode
+	keys     *EventMap
+	walls    *ui.WallRenderer
+	level    *Level
+	// obstacles holds the positions of the level's walls.
+	obstacles map[ui.Position]struct{}
+	// occupancy records what's in each cell, see occupied.
+	occupancy *occupancy
 }
 
 func (b *gameBoard) Update(g *game, delta time.Duration) {
-	b.snake.Update(b, g, delta)
+	for _, bot := range b.bots {
+		bot.Update(b, delta)
+	}
+	if b.autopilot != nil {
+		b.autopilot.Update(b, delta)
+	}
+	if b.hint != nil && b.hint.Visible {
+		b.hint.Cells = b.suggestedPath()
+	}
+	for _, s := range b.snakes() {
+		s.Update(b, g, delta)
+	}
 	b.apples.Update(b, delta)
+	b.powerUp.Update(b, delta)
+	b.SetEffects(b.snake.effects.statuses())
+	if p2 := b.playerTwo(); p2 != nil {
+		other := g.statsFor(p2)
+		b.LivesBox().SetText(fmt.Sprintf(twoPlayerLivesFormat, g.remainingLives, other.remainingLives))
+		b.ScoreBox().SetText(fmt.Sprintf(twoPlayerScoreFormat, g.score, other.score))
+		return
+	}
 	b.GameBoardRenderer.LivesBox().SetText(fmt.Sprintf(livesFormat, g.remainingLives))
 	b.GameBoardRenderer.ScoreBox().SetText(fmt.Sprintf(scoreFormat, g.score))
 }
 
+// snakes returns every snake on the board, starting with the first player's.
+func (b *gameBoard) snakes() []*snake {
+	if b.snake == nil {
+		return b.others
+	}
+	return append([]*snake{b.snake}, b.others...)
+}
+
+// playerTwo returns the second player's snake, or nil when there's only one player.
+func (b *gameBoard) playerTwo() *snake {
+	for _, s := range b.others {
+		if s.player == secondPlayer {
+			return s
+		}
+	}
+	return nil
+}
+
+// forEachSnake calls f with every snake on the board, starting with the first player's.
+// Unlike snakes, it doesn't allocate, so it's cheap enough to call on every move.
+func (b *gameBoard) forEachSnake(f func(*snake)) {
+	if b.snake != nil {
+		f(b.snake)
+	}
+	for _, s := range b.others {
+		f(s)
+	}
+}
+
+// snakeAt returns the snake, other than except, with a part of its body at pos.
+func (b *gameBoard) snakeAt(pos ui.Position, except *snake) *snake {
+	if owner := b.occupied().get(pos).owner; owner != except {
+		return owner
+	}
+	return nil
+}
+
+// startFor returns where a snake starts. With two players, the snakes start facing each
+// other on either side of the center.
+func (b *gameBoard) startFor(s *snake) ui.Position {
+	offset := (b.Right() - b.Left()) / 4
+	if s.player >= firstBot {
+		return b.botStart(s.player-firstBot, offset)
+	}
+	if b.playerTwo() == nil {
+		return b.start()
+	}
+	if s.player == secondPlayer {
+		return ui.Position{X: b.Center().X + offset, Y: b.Center().Y}
+	}
+	return ui.Position{X: b.Center().X - offset, Y: b.Center().Y}
+}
+
+// botStart returns where a bot starts, in a corner of the board away from the players.
+func (b *gameBoard) botStart(i int, offset int) ui.Position {
+	dy := (b.Bottom() - b.Top()) / 4
+	corners := []ui.Position{
+		{X: b.Center().X - offset, Y: b.Center().Y - dy},
+		{X: b.Center().X + offset, Y: b.Center().Y + dy},
+		{X: b.Center().X + offset, Y: b.Center().Y - dy},
+		{X: b.Center().X - offset, Y: b.Center().Y + dy},
+	}
+	return corners[i%len(corners)]
+}
+
+// notify passes an event on to the snake of the player it's meant for.
+func (b *gameBoard) notify(event Event) {
+	switch event {
+	case ToggleAutopilot:
+		b.toggleAutopilot()
+		return
+	case ToggleHint:
+		b.hint.Visible = !b.hint.Visible
+		b.hint.Cells = b.suggestedPath()
+		return
+	}
+	player, event := forPlayer(event)
+	for _, s := range b.snakes() {
+		if s.player == player {
+			s.Notify(event)
+		}
+	}
+}
+
 func (b *gameBoard) Center() ui.Position {
 	return ui.Position{
 		X: b.Left() + (b.Right()-b.Left())/2,
@@ -31,32 +160,222 @@ func (b *gameBoard) Center() ui.Position {
 	}
 }
 
+// freeCells returns the cells inside the board not taken by a snake, an apple or the
+// power-up, in order from the top left.
+func (b *gameBoard) freeCells() []ui.Position {
+	o := b.occupied()
+	var ret []ui.Position
+	for i, c := range o.cells {
+		if c.free() {
+			ret = append(ret, ui.Position{X: o.topLeft.X + i%o.width, Y: o.topLeft.Y + i/o.width})
+		}
+	}
+	return ret
+}
+
+// cellCount returns the number of cells inside the board that a snake can move through.
+func (b *gameBoard) cellCount() int {
+	return (b.Right()-b.Left()-1)*(b.Bottom()-b.Top()-1) - len(b.obstacles)
+}
+
+// cleared reports whether the snakes cover every cell of the board, leaving nowhere for
+// another apple.
+func (b *gameBoard) cleared() bool {
+	return b.occupied().snakeCells >= b.cellCount()
+}
+
+// randomAppleKind picks the kind of a new apple, using the configured spawn weights.
+func (b *gameBoard) randomAppleKind() ui.AppleKind {
+	total := 0
+	for _, k := range ui.AppleKinds {
+		total += b.cfg.AppleWeight(k)
+	}
+	if total == 0 {
+		return ui.NormalApple
+	}
+	n := b.rng.Intn(total)
+	for _, k := range ui.AppleKinds {
+		if n -= b.cfg.AppleWeight(k); n < 0 {
+			return k
+		}
+	}
+	return ui.NormalApple
+}
+
+// wrap moves a position that's just outside the board to the opposite edge.
+func (b *gameBoard) wrap(pos ui.Position) ui.Position {
+	switch {
+	case pos.X <= b.Left():
+		pos.X = b.Right() - 1
+	case pos.X >= b.Right():
+		pos.X = b.Left() + 1
+	}
+	switch {
+	case pos.Y <= b.Top():
+		pos.Y = b.Bottom() - 1
+	case pos.Y >= b.Bottom():
+		pos.Y = b.Top() + 1
+	}
+	return pos
+}
+
+// IsInside reports whether a position is free of the board's border and any obstacles.
 func (b *gameBoard) IsInside(pos ui.Position) bool {
+	return b.withinBorder(pos) && !b.isObstacle(pos)
+}
+
+func (b *gameBoard) withinBorder(pos ui.Position) bool {
 	return pos.X > b.Left() && pos.X < b.Right() &&
 		pos.Y > b.Top() && pos.Y < b.Bottom()
 }
 
+func (b *gameBoard) isObstacle(pos ui.Position) bool {
+	_, ok := b.obstacles[pos]
+	return ok
+}
+
+// start returns where the head of the snake is placed at the start of a game.
+func (b *gameBoard) start() ui.Position {
+	if b.level == nil {
+		return b.Center()
+	}
+	return b.fromLevel(b.level.start)
+}
+
+// fromLevel converts the position of a level tile to a position on the board.
+func (b *gameBoard) fromLevel(tile ui.Position) ui.Position {
+	return ui.Position{X: b.Left() + 1 + tile.X, Y: b.Top() + 1 + tile.Y}
+}
+
+// setLevel lays the board out as the given level. The board takes the size of the level,
+// so it no longer follows the size of the screen.
+func (b *gameBoard) setLevel(level *Level) error {
+	if len(b.others) > 0 {
+		return ErrSinglePlayerOnly
+	}
+	if err := level.fits(b.snake.startingLength); err != nil {
+		return err
+	}
+	obstacles := make(map[ui.Position]struct{}, len(level.walls))
+	tiles := make([]ui.Position, 0, len(level.walls))
+	for _, w := range level.walls {
+		pos := b.fromLevel(w)
+		obstacles[pos] = struct{}{}
+		tiles = append(tiles, pos)
+	}
+
+	b.SetSize(
+		b.Width()+level.width-(b.Right()-b.Left()-1),
+		b.Height()+level.height-(b.Bottom()-b.Top()-1),
+	)
+	b.level = level
+	b.obstacles = obstacles
+	b.walls.Tiles = tiles
+	b.snake.startDir = level.Direction
+	b.snake.ResetTo(b.start())
+	b.apples.reset(b)
+	b.powerUp.reset(b)
+	return nil
+}
+
+// Resize fits the board to a screen of the given size. Anything left outside the new
+// bounds is moved back onto the board. A board laid out from a level keeps its size.
+func (b *gameBoard) Resize(width int, height int) {
+	if b.level != nil {
+		return
+	}
+	b.SetSize(b.cfg.BoardSize(width, height))
+	for _, s := range b.snakes() {
+		s.fitInside(b)
+	}
+	b.apples.ForEach(func(a *apple) {
+		if !b.IsInside(a.Pos) {
+			a.setPos(b)
+		}
+	})
+	if b.powerUp.Visible && !b.IsInside(b.powerUp.Pos) {
+		b.powerUp.reset(b)
+	}
+}
+
 func (b *gameBoard) keyHandler(key *tcell.EventKey) {
-	b.snake.Notify(eventMap.GetEventFromKey(key))
+	if b.demo != nil {
+		return
+	}
+	b.notify(b.keys.GetEventFromKey(key))
 }
 
-func (b *gameBoard) reset() {
-	b.snake.ResetTo(b.Center())
+// reset restores the board to its starting layout. Reseeding before anything is placed
+// means the same seed always produces the same game.
+func (b *gameBoard) reset(seed int64) {
+	b.rng.Seed(seed)
+	for _, s := range b.snakes() {
+		s.ResetTo(b.startFor(s))
+	}
+	if b.demo != nil {
+		b.demo.place(b)
+	}
+	b.apples.reset(b)
+	b.powerUp.reset(b)
+}
+
+// addSnakes puts the snakes of the players and bots on the board at their starting positions.
+func (b *gameBoard) addSnakes() {
+	cfg := b.cfg
+	b.snake = newSnakeOfLength(b.Center(), cfg.SnakeStartingLength())
+	if cfg.Players() == 2 {
+		p2 := newSnakeOfLength(b.Center(), cfg.SnakeStartingLength())
+		p2.player = secondPlayer
+		p2.Player = secondPlayer
+		p2.startDir = left
+		b.others = append(b.others, p2)
+		for _, s := range b.snakes() {
+			s.ResetTo(b.startFor(s))
+		}
+	}
+	for i := range cfg.Bots() {
+		s := newSnakeOfLength(b.Center(), cfg.SnakeStartingLength())
+		s.player = firstBot + i
+		s.Player = s.player
+		// bots on the left of the board start heading right, and those on the right head left
+		if i%4 == 1 || i%4 == 2 {
+			s.startDir = left
+		} else {
+			s.startDir = right
+		}
+		s.ResetTo(b.startFor(s))
+		b.others = append(b.others, s)
+		b.bots = append(b.bots, newBot(s, cfg.BotDifficulty()))
+	}
 }
 
 func newGameBoard(ul ui.Position, width int, height int, cfg *Config) *gameBoard {
 	ret := gameBoard{
 		GameBoardRenderer: ui.NewGameBoardRenderer(ul, width, height),
+		rng:               rand.New(rand.NewSource(cfg.Seed())),
+		cfg:               cfg,
+		wallMode:          cfg.WallMode(),
+		walls:             &ui.WallRenderer{},
+		hint:              &ui.TrailRenderer{},
+		keys:              &EventMap{twoPlayer: cfg.Players() == 2},
 	}
 	ret.SetKeyEventCallback(ret.keyHandler)
 	ret.LivesBox().SetText(fmt.Sprintf(livesFormat, cfg.NumberOfLives()))
+	ret.SetMode(ret.wallMode.String())
 
-	s := newSnakeOfLength(ret.Center(), cfg.SnakeStartingLength())
-	ret.snake = s
+	ret.addSnakes()
+	if cfg.Players() == 2 {
+		ret.LivesBox().SetText(fmt.Sprintf(twoPlayerLivesFormat, cfg.NumberOfLives(), cfg.NumberOfLives()))
+	}
 	a := newApples(&ret, cfg.MaxNumberOfApples())
 	ret.apples = a
 
-	_ = ret.Add(s)
+	_ = ret.Add(ret.walls)
+	_ = ret.Add(ret.hint)
+	_ = ret.Add(&ret.powerUp)
+	for _, s := range ret.snakes() {
+		_ = ret.Add(s)
+	}
 	a.ForEach(func(a *apple) {
 		_ = ret.Add(a)
 	})
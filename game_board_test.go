@@ -42,4 +42,148 @@ func Test_GameBoard(t *testing.T) {
 			require.False(t, board.IsInside(ui.Position{X: 2, Y: board.Bottom()}))
 		})
 	})
+
+	t.Run("non-square board", func(t *testing.T) {
+		board := newGameBoard(ui.Position{X: 0, Y: 0}, 30, 16, &Config{})
+
+		t.Run("center", func(t *testing.T) {
+			require.Equal(t, ui.Position{X: 14, Y: 9}, board.Center())
+		})
+
+		t.Run("isInside", func(t *testing.T) {
+			require.True(t, board.IsInside(ui.Position{X: 28, Y: 14}))
+			require.False(t, board.IsInside(ui.Position{X: 29, Y: 14}))
+			require.False(t, board.IsInside(ui.Position{X: 28, Y: 15}))
+		})
+
+		t.Run("apples are placed anywhere the snake isn't", func(t *testing.T) {
+			board := newGameBoard(ui.Position{X: 0, Y: 0}, 30, 16, &Config{})
+			board.apples = nil
+			seen := make(map[ui.Position]struct{})
+			a := newApple(board)
+			for range 5000 {
+				a.setPos(board)
+				requireWithinBounds(t, board, a.Pos)
+				seen[a.Pos] = struct{}{}
+			}
+
+			cells := (board.Right() - board.Left() - 1) * (board.Bottom() - board.Top() - 1)
+			require.Len(t, seen, cells-len(board.snake.Body))
+			for _, p := range board.snake.Body {
+				require.NotContains(t, seen, p)
+			}
+		})
+	})
+
+	t.Run("resize", func(t *testing.T) {
+		t.Run("board is limited to maximum size", func(t *testing.T) {
+			board := newGameBoard(ui.Position{X: 0, Y: 0}, 20, 20, &Config{})
+
+			board.Resize(100, 100)
+
+			require.Equal(t, DefaultBoardWidth, board.Width())
+			require.Equal(t, DefaultBoardHeight, board.Height())
+		})
+
+		t.Run("board uses configured size", func(t *testing.T) {
+			board := newGameBoard(ui.Position{X: 0, Y: 0}, 20, 20, &Config{boardWidth: 60, boardHeight: 25})
+
+			board.Resize(100, 100)
+
+			require.Equal(t, 60, board.Width())
+			require.Equal(t, 25, board.Height())
+		})
+
+		t.Run("board fills the terminal", func(t *testing.T) {
+			board := newGameBoard(ui.Position{X: 0, Y: 0}, 20, 20, &Config{fillTerminal: true})
+
+			board.Resize(100, 50)
+
+			require.Equal(t, 100, board.Width())
+			require.Equal(t, 50, board.Height())
+		})
+
+		t.Run("snake and apples outside the board are moved onto it", func(t *testing.T) {
+			board := newGameBoard(ui.Position{X: 0, Y: 0}, 30, 30, &Config{})
+			board.snake.Body = []ui.Position{{X: 24, Y: 25}, {X: 25, Y: 25}, {X: 26, Y: 25}}
+			board.apples[0].Pos = ui.Position{X: 27, Y: 27}
+
+			board.Resize(20, 20)
+
+			for _, p := range board.snake.Body {
+				requireWithinBounds(t, board, p)
+			}
+			board.apples.ForEach(func(a *apple) {
+				requireWithinBounds(t, board, a.Pos)
+			})
+		})
+	})
+	t.Run("level", func(t *testing.T) {
+		newLevelBoard := func(t *testing.T, header ...string) *gameBoard {
+			board := newGameBoard(ui.Position{X: 0, Y: 0}, 30, 30, &Config{})
+			require.NoError(t, board.setLevel(loadTestLevel(t, header...)))
+			return board
+		}
+
+		t.Run("board takes the size of the level", func(t *testing.T) {
+			board := newLevelBoard(t)
+
+			require.Equal(t, 20, board.Width())
+			require.Equal(t, 15, board.Height())
+			require.Equal(t, 18, board.Right()-board.Left()-1)
+			require.Equal(t, 9, board.Bottom()-board.Top()-1)
+		})
+
+		t.Run("walls aren't inside", func(t *testing.T) {
+			board := newLevelBoard(t)
+			wall := board.fromLevel(ui.Position{X: 8, Y: 1})
+
+			require.False(t, board.IsInside(wall))
+			require.True(t, board.withinBorder(wall))
+			require.True(t, board.IsInside(board.fromLevel(ui.Position{X: 7, Y: 1})))
+			require.Equal(t, []ui.Position{wall, board.fromLevel(ui.Position{X: 9, Y: 1})}, board.walls.Tiles)
+		})
+
+		t.Run("snake starts at the level's start facing its direction", func(t *testing.T) {
+			board := newLevelBoard(t, "direction: up")
+			start := board.fromLevel(ui.Position{X: 8, Y: 4})
+
+			require.Equal(t, start, board.snake.head())
+			require.Equal(t, up, board.snake.dir)
+			require.Equal(t, []ui.Position{
+				{X: start.X, Y: start.Y + 2}, {X: start.X, Y: start.Y + 1}, start,
+			}, board.snake.Body)
+
+			board.reset(1)
+			require.Equal(t, start, board.snake.head())
+			require.Equal(t, up, board.snake.dir)
+		})
+
+		t.Run("apples are never placed on walls", func(t *testing.T) {
+			board := newLevelBoard(t)
+
+			for seed := range int64(200) {
+				board.reset(seed)
+				board.apples.ForEach(func(a *apple) {
+					require.True(t, board.IsInside(a.Pos))
+				})
+			}
+		})
+
+		t.Run("board keeps its size when the screen is resized", func(t *testing.T) {
+			board := newLevelBoard(t)
+
+			board.Resize(100, 100)
+
+			require.Equal(t, 20, board.Width())
+			require.Equal(t, 15, board.Height())
+		})
+
+		t.Run("level without room for the snake is rejected", func(t *testing.T) {
+			board := newGameBoard(ui.Position{X: 0, Y: 0}, 30, 30, &Config{snakeStartingLength: 10})
+
+			require.Error(t, board.setLevel(loadTestLevel(t)))
+			require.Nil(t, board.level)
+		})
+	})
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"math/rand"
 	"slices"
 	"snake/ui"
 	"sync"
@@ -18,22 +19,30 @@ func Test_NewGameState(t *testing.T) {
 	require.NotNil(t, g.gameBoard)
 }
 
+func Test_NewGameUsesConfiguredBoardSize(t *testing.T) {
+	g := newSnakeGame(&Config{boardWidth: 50, boardHeight: 20}, 80, 40)
+
+	require.Equal(t, 50, g.gameBoard.Width())
+	require.Equal(t, 20, g.gameBoard.Height())
+}
+
 func Test_RunGame(t *testing.T) {
 	simScreen := setupScreen(t, 20, 20)
-	game := spyGame{}
 
 	t.Run("executes game lifecycle", func(t *testing.T) {
+		game := spyGame{}
 		go func() {
 			require.NoError(t, simScreen.PostEvent(tcell.NewEventKey(tcell.KeyUp, tcell.RuneUArrow, tcell.ModNone)))
 			require.NoError(t, simScreen.PostEvent(tcell.NewEventKey(tcell.KeyCtrlC, 'C', tcell.ModCtrl)))
 		}()
-		require.NoError(t, RunGame(&game, simScreen))
+		require.NoError(t, RunGame(&game, simScreen, newFakeClock(0)))
 		game.assertNotified(t)
 		game.assertUpdated(t)
 		game.assertDrawn(t)
 	})
 
 	t.Run("game runs until finished", func(t *testing.T) {
+		game := spyGame{}
 		var wg sync.WaitGroup
 		wg.Add(1)
 		go func() {
@@ -41,9 +50,86 @@ func Test_RunGame(t *testing.T) {
 			<-time.After(time.Millisecond * 500)
 			require.NoError(t, simScreen.PostEvent(tcell.NewEventKey(tcell.KeyCtrlC, 'C', tcell.ModCtrl)))
 		}()
-		require.NoError(t, RunGame(&game, simScreen))
+		require.NoError(t, RunGame(&game, simScreen, SystemClock()))
 		wg.Wait()
 	})
+
+	t.Run("updates once per frame when frames take no time", func(t *testing.T) {
+		game := spyGame{stopAfter: 10}
+
+		require.NoError(t, RunGame(&game, simScreen, newFakeClock(0)))
+
+		require.Len(t, game.deltas, 10)
+		for _, d := range game.deltas {
+			require.Equal(t, TickDuration, d)
+		}
+	})
+
+	t.Run("catches up with fixed ticks when frames are slow", func(t *testing.T) {
+		game := spyGame{stopAfter: 9}
+
+		require.NoError(t, RunGame(&game, simScreen, newFakeClock(2*FrameDuration)))
+
+		require.Len(t, game.deltas, 9)
+		for _, d := range game.deltas {
+			require.Equal(t, TickDuration, d)
+		}
+		// no time has passed when the first frame is drawn
+		require.Equal(t, 3*(game.draws-1), len(game.deltas), "expected three ticks per frame")
+	})
+
+	t.Run("limits catch up after a stall", func(t *testing.T) {
+		game := spyGame{stopAfter: 1}
+
+		require.NoError(t, RunGame(&game, simScreen, newFakeClock(time.Hour)))
+
+		require.Len(t, game.deltas, int(MaxFrameDelta/TickDuration))
+	})
+}
+
+func Test_GameSeed(t *testing.T) {
+	applePositions := func(g *game) []ui.Position {
+		var ret []ui.Position
+		g.gameBoard.apples.ForEach(func(a *apple) {
+			ret = append(ret, a.Pos)
+		})
+		return ret
+	}
+
+	t.Run("configured seed is used for every game", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.SetSeed(1234)
+		g := newSnakeGame(cfg, 20, 20)
+
+		g.reset()
+		first := applePositions(g)
+		g.gameBoard.apples.ForEach(func(a *apple) { a.eaten = true })
+		g.gameBoard.apples.Update(g.gameBoard, 0)
+		g.reset()
+
+		assert.Equal(t, int64(1234), g.seed)
+		assert.Equal(t, first, applePositions(g))
+	})
+
+	t.Run("same seed produces the same game", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.SetSeed(99)
+		first := newSnakeGame(cfg, 20, 20)
+		second := newSnakeGame(cfg, 20, 20)
+
+		first.reset()
+		second.reset()
+
+		assert.Equal(t, applePositions(first), applePositions(second))
+	})
+
+	t.Run("unseeded games pick a non-zero seed", func(t *testing.T) {
+		g := newSnakeGame(&Config{}, 20, 20)
+
+		g.reset()
+
+		assert.NotZero(t, g.seed)
+	})
 }
 
 func Test_Game(t *testing.T) {
@@ -55,6 +141,8 @@ func Test_Game(t *testing.T) {
 	setup := func() {
 		b = &gameBoard{
 			GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, 9, 9),
+			rng:               rand.New(rand.NewSource(1)),
+			cfg:               normalApplesConfig(),
 		}
 		pos := b.Center()
 		a = apples{
@@ -64,21 +152,29 @@ func Test_Game(t *testing.T) {
 		b.snake = s
 		b.apples = a
 		g = game{
-			Manager:        ui.NewManager(),
-			gameBoard:      b,
-			cfg:            &Config{},
-			remainingLives: DefaultNumberOfLives,
-			currentState:   new(menuState),
+			Manager:      ui.NewManager(),
+			gameBoard:    b,
+			cfg:          &Config{},
+			stats:        stats{remainingLives: DefaultNumberOfLives},
+			currentState: new(menuState),
 		}
+		g.mainMenu = newMainMenu(&g)
 	}
 
 	simulateEvent := func(g *game, event Event) {
 		g.currentState.handle(g, event)
 	}
 
+	// starting a game places the apples at random, so put one back in front of the snake
+	startGame := func(g *game) {
+		simulateEvent(g, StartGame)
+		pos := b.Center()
+		a[0].Pos = ui.Position{X: pos.X + 1, Y: pos.Y}
+	}
+
 	t.Run("player earns points for eating apples", func(t *testing.T) {
 		setup()
-		simulateEvent(&g, StartGame)
+		startGame(&g)
 
 		g.Update(moveDelta)
 
@@ -87,7 +183,7 @@ func Test_Game(t *testing.T) {
 
 	t.Run("crashing reduces remainingLives remaining", func(t *testing.T) {
 		setup()
-		simulateEvent(&g, StartGame)
+		startGame(&g)
 
 		simulate(g.gameBoard.snake, &g, MoveRight, MoveDown, MoveLeft, MoveUp)
 
@@ -137,10 +233,13 @@ func Test_Game(t *testing.T) {
 }
 
 type spyGame struct {
-	notified bool
-	updated  bool
-	drawn    bool
-	finished bool
+	notified  bool
+	updated   bool
+	drawn     bool
+	finished  bool
+	draws     int
+	stopAfter int
+	deltas    []time.Duration
 }
 
 func (s *spyGame) Handle(event tcell.Event) {
@@ -153,12 +252,17 @@ func (s *spyGame) Handle(event tcell.Event) {
 	s.notified = true
 }
 
-func (s *spyGame) Update(time.Duration) {
+func (s *spyGame) Update(delta time.Duration) {
 	s.updated = true
+	s.deltas = append(s.deltas, delta)
+	if s.stopAfter > 0 && len(s.deltas) >= s.stopAfter {
+		s.finished = true
+	}
 }
 
 func (s *spyGame) Draw(tcell.Screen) {
 	s.drawn = true
+	s.draws += 1
 }
 
 func (s *spyGame) Finished() bool {
@@ -177,9 +281,125 @@ func (s *spyGame) assertDrawn(t *testing.T) {
 	assert.True(t, s.drawn, "game was never drawn")
 }
 
+// fakeClock is a virtual Clock. Sleeping advances time by the requested duration
+// plus the configured work time, simulating frames that take work to produce.
+type fakeClock struct {
+	now      time.Time
+	workTime time.Duration
+}
+
+func newFakeClock(workTime time.Duration) *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0), workTime: workTime}
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeClock) Sleep(d time.Duration) {
+	f.now = f.now.Add(max(d, 0) + f.workTime)
+}
+
 func setupScreen(t *testing.T, height, width int) tcell.SimulationScreen {
 	ret := tcell.NewSimulationScreen("")
 	require.NoError(t, ret.Init())
 	ret.SetSize(height, width)
 	return ret
 }
+
+func Test_TwoPlayers(t *testing.T) {
+	var g *game
+	var one, two *snake
+
+	setup := func() {
+		cfg := normalApplesConfig()
+		cfg.players = 2
+		// a fixed seed puts the apples in the same place every run
+		cfg.seed = 1
+		g = newSnakeGame(cfg, 40, 20)
+		g.currentState.handle(g, StartGame)
+		one, two = g.gameBoard.snake, g.gameBoard.playerTwo()
+	}
+
+	t.Run("snakes start facing each other", func(t *testing.T) {
+		setup()
+
+		require.NotNil(t, two)
+		require.Less(t, one.head().X, two.head().X)
+		require.Equal(t, right, one.dir)
+		require.Equal(t, left, two.dir)
+	})
+
+	t.Run("arrow keys turn player two", func(t *testing.T) {
+		setup()
+
+		g.Handle(keyPress(tcell.KeyUp, 0))
+		g.Handle(keyPress(tcell.KeyRune, 's'))
+
+		require.Equal(t, up, two.dir)
+		require.Equal(t, down, one.dir)
+	})
+
+	t.Run("hud shows both players", func(t *testing.T) {
+		setup()
+		// an apple placed in front of player one would be eaten on the first tick
+		g.gameBoard.apples = nil
+		g.playerTwoStats.score = 7
+
+		g.Update(TickDuration)
+
+		require.Equal(t, "Score: 0 | 7", g.gameBoard.ScoreBox().Text())
+		require.Equal(t, "Lives: 3 | 3", g.gameBoard.LivesBox().Text())
+	})
+
+	t.Run("head to head costs both players a life", func(t *testing.T) {
+		setup()
+		one.Body = []ui.Position{{X: 5, Y: 5}, {X: 6, Y: 5}}
+		two.Body = []ui.Position{{X: 8, Y: 5}, {X: 7, Y: 5}}
+
+		one.Update(g.gameBoard, g, one.moveDelay)
+
+		require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+		require.Equal(t, DefaultNumberOfLives-1, g.playerTwoStats.remainingLives)
+	})
+
+	t.Run("running into a body costs only the mover a life", func(t *testing.T) {
+		setup()
+		one.Body = []ui.Position{{X: 5, Y: 5}, {X: 6, Y: 5}}
+		two.Body = []ui.Position{{X: 7, Y: 4}, {X: 7, Y: 5}, {X: 7, Y: 6}}
+		two.dir = down
+
+		one.Update(g.gameBoard, g, one.moveDelay)
+
+		require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+		require.Equal(t, DefaultNumberOfLives, g.playerTwoStats.remainingLives)
+	})
+
+	t.Run("game ends when either player runs out of lives", func(t *testing.T) {
+		setup()
+		g.playerTwoStats.remainingLives = 0
+
+		require.True(t, g.gameOver())
+		require.Equal(t, PlayerOneWinsText, g.winner())
+	})
+
+	t.Run("winner", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			one, two stats
+			want     string
+		}{
+			{"player with lives left wins", stats{remainingLives: 0, score: 9}, stats{remainingLives: 1}, PlayerTwoWinsText},
+			{"higher score wins when both are out", stats{score: 3}, stats{score: 2}, PlayerOneWinsText},
+			{"equal scores draw", stats{score: 3}, stats{score: 3}, DrawText},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				setup()
+				g.stats, g.playerTwoStats = tt.one, tt.two
+
+				require.Equal(t, tt.want, g.winner())
+			})
+		}
+	})
+}
@@ -0,0 +1,324 @@
+// Package grid finds paths across the board, treating walls and snakes as blocked cells.
+package grid
+
+import (
+	"container/heap"
+	"errors"
+	"snake/ui"
+)
+
+// Grid is the playable area of a board, a rectangle of cells some of which are blocked.
+type Grid struct {
+	topLeft ui.Position
+	width   int
+	height  int
+	blocked []bool
+	// wrap joins opposite edges of the grid, so moving off one edge enters the other.
+	wrap bool
+}
+
+// New returns an empty grid of the given size, with topLeft as its first cell.
+func New(topLeft ui.Position, width int, height int) *Grid {
+	return &Grid{
+		topLeft: topLeft,
+		width:   width,
+		height:  height,
+		blocked: make([]bool, width*height),
+	}
+}
+
+// Width returns the number of columns in the grid.
+func (g *Grid) Width() int {
+	return g.width
+}
+
+// Height returns the number of rows in the grid.
+func (g *Grid) Height() int {
+	return g.height
+}
+
+// SetWrap sets whether moving off an edge of the grid enters the opposite edge.
+func (g *Grid) SetWrap(wrap bool) {
+	g.wrap = wrap
+}
+
+// Block marks cells as blocked. Cells outside the grid are ignored.
+func (g *Grid) Block(cells ...ui.Position) {
+	for _, c := range cells {
+		if g.Contains(c) {
+			g.blocked[g.index(c)] = true
+		}
+	}
+}
+
+// Unblock marks cells as free. Cells outside the grid are ignored.
+func (g *Grid) Unblock(cells ...ui.Position) {
+	for _, c := range cells {
+		if g.Contains(c) {
+			g.blocked[g.index(c)] = false
+		}
+	}
+}
+
+// Contains reports whether a cell is part of the grid.
+func (g *Grid) Contains(c ui.Position) bool {
+	return c.X >= g.topLeft.X && c.X < g.topLeft.X+g.width &&
+		c.Y >= g.topLeft.Y && c.Y < g.topLeft.Y+g.height
+}
+
+// Blocked reports whether a cell can't be moved into, which includes every cell outside
+// the grid.
+func (g *Grid) Blocked(c ui.Position) bool {
+	return !g.Contains(c) || g.blocked[g.index(c)]
+}
+
+// Step returns the cell reached by moving from c by dx and dy, which wraps around the grid's
+// edges when the grid wraps.
+func (g *Grid) Step(c ui.Position, dx int, dy int) ui.Position {
+	next := ui.Position{X: c.X + dx, Y: c.Y + dy}
+	if g.wrap {
+		next.X = g.topLeft.X + mod(next.X-g.topLeft.X, g.width)
+		next.Y = g.topLeft.Y + mod(next.Y-g.topLeft.Y, g.height)
+	}
+	return next
+}
+
+// offsets are the moves between neighbouring cells: up, right, down and left. Searches try
+// them in this order, so paths of equal length are always chosen the same way.
+var offsets = [4]ui.Position{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+
+// Neighbours returns the free cells next to c.
+func (g *Grid) Neighbours(c ui.Position) []ui.Position {
+	ret := make([]ui.Position, 0, len(offsets))
+	for _, o := range offsets {
+		if next := g.Step(c, o.X, o.Y); !g.Blocked(next) {
+			ret = append(ret, next)
+		}
+	}
+	return ret
+}
+
+// BFS returns the shortest path from start to the nearest cell for which goal returns true.
+// The path leaves out start and ends at the goal. Start itself may be blocked, since it's
+// usually the head of a snake.
+func (g *Grid) BFS(start ui.Position, goal func(ui.Position) bool) ([]ui.Position, bool) {
+	if !g.Contains(start) {
+		return nil, false
+	}
+	prev := make([]int, len(g.blocked))
+	for i := range prev {
+		prev[i] = -1
+	}
+	from := g.index(start)
+	prev[from] = from
+	queue := []int{from}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		c := g.position(i)
+		if i != from && goal(c) {
+			return g.walkBack(prev, from, i), true
+		}
+		for _, next := range g.Neighbours(c) {
+			if n := g.index(next); prev[n] < 0 {
+				prev[n] = i
+				queue = append(queue, n)
+			}
+		}
+	}
+	return nil, false
+}
+
+// AStar returns the shortest path from start to goal, guided by the distance left to the
+// goal. Like BFS, the path leaves out start and start may be blocked.
+func (g *Grid) AStar(start ui.Position, goal ui.Position) ([]ui.Position, bool) {
+	if !g.Contains(start) || g.Blocked(goal) {
+		return nil, false
+	}
+	from, to := g.index(start), g.index(goal)
+	prev := make([]int, len(g.blocked))
+	cost := make([]int, len(g.blocked))
+	for i := range prev {
+		prev[i] = -1
+	}
+	prev[from] = from
+	open := &queue{{cell: from, priority: g.Distance(start, goal)}}
+	for open.Len() > 0 {
+		i := heap.Pop(open).(item).cell
+		if i == to {
+			return g.walkBack(prev, from, to), true
+		}
+		for _, next := range g.Neighbours(g.position(i)) {
+			n := g.index(next)
+			if c := cost[i] + 1; prev[n] < 0 || c < cost[n] {
+				prev[n], cost[n] = i, c
+				heap.Push(open, item{cell: n, priority: c + g.Distance(next, goal)})
+			}
+		}
+	}
+	return nil, false
+}
+
+// Distance returns the number of moves between two cells if nothing is in the way, taking the
+// short way round when the grid wraps.
+func (g *Grid) Distance(a ui.Position, b ui.Position) int {
+	dx, dy := abs(a.X-b.X), abs(a.Y-b.Y)
+	if g.wrap {
+		dx, dy = min(dx, g.width-dx), min(dy, g.height-dy)
+	}
+	return dx + dy
+}
+
+// FloodFill counts the free cells that can be reached from start, counting start itself even
+// when it's blocked.
+func (g *Grid) FloodFill(start ui.Position) int {
+	count := 0
+	g.fill(start, func(int) { count += 1 })
+	return count
+}
+
+// Reachable returns the cells that can be reached from start, in the order they're found.
+// Like FloodFill, start is included even when it's blocked.
+func (g *Grid) Reachable(start ui.Position) []ui.Position {
+	var ret []ui.Position
+	g.fill(start, func(i int) { ret = append(ret, g.position(i)) })
+	return ret
+}
+
+// Free returns every free cell of the grid, row by row from the top left.
+func (g *Grid) Free() []ui.Position {
+	var ret []ui.Position
+	for i, blocked := range g.blocked {
+		if !blocked {
+			ret = append(ret, g.position(i))
+		}
+	}
+	return ret
+}
+
+// fill visits each cell reachable from start once, breadth first.
+func (g *Grid) fill(start ui.Position, visit func(int)) {
+	if !g.Contains(start) {
+		return
+	}
+	seen := make([]bool, len(g.blocked))
+	from := g.index(start)
+	seen[from] = true
+	queue := []int{from}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		visit(i)
+		for _, next := range g.Neighbours(g.position(i)) {
+			if n := g.index(next); !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+}
+
+// ErrNoHamiltonianCycle is returned for grids without a cycle through every cell, which are
+// those with an odd number of cells or a single row or column.
+var ErrNoHamiltonianCycle = errors.New("grid has no hamiltonian cycle")
+
+// HamiltonianCycle returns a cycle that visits every cell of the grid once, starting from
+// the top left cell. Blocked cells are treated as free, since the cycle describes the grid
+// itself rather than what's on it.
+func (g *Grid) HamiltonianCycle() ([]ui.Position, error) {
+	w, h := g.width, g.height
+	if w < 2 || h < 2 || (w*h)%2 != 0 {
+		return nil, ErrNoHamiltonianCycle
+	}
+	transpose := h%2 != 0
+	if transpose {
+		w, h = h, w
+	}
+	// run right along the top row, zigzag down through the remaining columns, then come back
+	// up the first column, which works for any even number of rows
+	cycle := make([]ui.Position, 0, w*h)
+	for x := range w {
+		cycle = append(cycle, ui.Position{X: x, Y: 0})
+	}
+	for y := 1; y < h; y++ {
+		for i := range w - 1 {
+			x := w - 1 - i
+			if y%2 == 0 {
+				x = 1 + i
+			}
+			cycle = append(cycle, ui.Position{X: x, Y: y})
+		}
+	}
+	for y := h - 1; y > 0; y-- {
+		cycle = append(cycle, ui.Position{X: 0, Y: y})
+	}
+	for i, c := range cycle {
+		if transpose {
+			c.X, c.Y = c.Y, c.X
+		}
+		cycle[i] = ui.Position{X: g.topLeft.X + c.X, Y: g.topLeft.Y + c.Y}
+	}
+	return cycle, nil
+}
+
+// walkBack follows the links in prev from end back to start, returning the cells in between
+// in the order they're visited.
+func (g *Grid) walkBack(prev []int, start int, end int) []ui.Position {
+	var path []ui.Position
+	for i := end; i != start; i = prev[i] {
+		path = append(path, g.position(i))
+	}
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	return path
+}
+
+func (g *Grid) index(c ui.Position) int {
+	return (c.Y-g.topLeft.Y)*g.width + c.X - g.topLeft.X
+}
+
+func (g *Grid) position(i int) ui.Position {
+	return ui.Position{X: g.topLeft.X + i%g.width, Y: g.topLeft.Y + i/g.width}
+}
+
+func mod(a, b int) int {
+	return ((a % b) + b) % b
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// item is a cell waiting in a queue, ordered by priority.
+type item struct {
+	cell     int
+	priority int
+}
+
+// queue is a priority queue of cells for container/heap, lowest priority first.
+type queue []item
+
+func (q queue) Len() int { return len(q) }
+
+func (q queue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	// settle ties by cell index, so equal paths are always chosen the same way
+	return q[i].cell < q[j].cell
+}
+
+func (q queue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *queue) Push(x any) { *q = append(*q, x.(item)) }
+
+func (q *queue) Pop() any {
+	old := *q
+	ret := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ret
+}
@@ -0,0 +1,66 @@
+package grid
+
+import (
+	"fmt"
+	"snake/ui"
+	"testing"
+)
+
+// benchmarkSizes are the default board's playable area and a few larger boards.
+var benchmarkSizes = []int{39, 100, 250}
+
+// benchmarkGrid returns a square grid with a wall down the middle that has a gap at the
+// bottom, so searches have to go the long way round.
+func benchmarkGrid(size int) *Grid {
+	g := New(ui.Position{}, size, size)
+	for y := range size - 1 {
+		g.Block(ui.Position{X: size / 2, Y: y})
+	}
+	return g
+}
+
+func BenchmarkBFS(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dx%d", size, size), func(b *testing.B) {
+			g := benchmarkGrid(size)
+			goal := at(size-1, 0)
+			for range b.N {
+				g.BFS(ui.Position{}, goal)
+			}
+		})
+	}
+}
+
+func BenchmarkAStar(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dx%d", size, size), func(b *testing.B) {
+			g := benchmarkGrid(size)
+			goal := ui.Position{X: size - 1, Y: 0}
+			for range b.N {
+				g.AStar(ui.Position{}, goal)
+			}
+		})
+	}
+}
+
+func BenchmarkFloodFill(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dx%d", size, size), func(b *testing.B) {
+			g := benchmarkGrid(size)
+			for range b.N {
+				g.FloodFill(ui.Position{})
+			}
+		})
+	}
+}
+
+func BenchmarkHamiltonianCycle(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dx%d", size, size+1), func(b *testing.B) {
+			g := New(ui.Position{}, size, size+1)
+			for range b.N {
+				_, _ = g.HamiltonianCycle()
+			}
+		})
+	}
+}
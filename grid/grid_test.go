@@ -0,0 +1,245 @@
+package grid
+
+import (
+	"fmt"
+	"snake/ui"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func at(x, y int) func(ui.Position) bool {
+	return func(p ui.Position) bool {
+		return p == ui.Position{X: x, Y: y}
+	}
+}
+
+func Test_Grid(t *testing.T) {
+	t.Run("contains cells from the top left", func(t *testing.T) {
+		g := New(ui.Position{X: 2, Y: 3}, 4, 5)
+
+		require.True(t, g.Contains(ui.Position{X: 2, Y: 3}))
+		require.True(t, g.Contains(ui.Position{X: 5, Y: 7}))
+		require.False(t, g.Contains(ui.Position{X: 1, Y: 3}))
+		require.False(t, g.Contains(ui.Position{X: 6, Y: 7}))
+		require.False(t, g.Contains(ui.Position{X: 5, Y: 8}))
+	})
+
+	t.Run("cells outside are blocked", func(t *testing.T) {
+		g := New(ui.Position{}, 4, 4)
+
+		require.True(t, g.Blocked(ui.Position{X: -1, Y: 0}))
+		require.False(t, g.Blocked(ui.Position{X: 0, Y: 0}))
+	})
+
+	t.Run("blocks and unblocks cells", func(t *testing.T) {
+		g := New(ui.Position{}, 4, 4)
+		c := ui.Position{X: 1, Y: 2}
+
+		g.Block(c, ui.Position{X: 10, Y: 10})
+		require.True(t, g.Blocked(c))
+
+		g.Unblock(c)
+		require.False(t, g.Blocked(c))
+	})
+
+	t.Run("neighbours leave out blocked cells", func(t *testing.T) {
+		g := New(ui.Position{}, 3, 3)
+		g.Block(ui.Position{X: 1, Y: 0})
+
+		require.Equal(t, []ui.Position{{X: 2, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 1}}, g.Neighbours(ui.Position{X: 1, Y: 1}))
+	})
+
+	t.Run("neighbours wrap around the edges", func(t *testing.T) {
+		g := New(ui.Position{X: 1, Y: 1}, 3, 3)
+		g.SetWrap(true)
+
+		require.Equal(t, []ui.Position{{X: 1, Y: 3}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 1}}, g.Neighbours(ui.Position{X: 1, Y: 1}))
+	})
+}
+
+func Test_BFS(t *testing.T) {
+	t.Run("finds the shortest path", func(t *testing.T) {
+		g := New(ui.Position{}, 5, 5)
+
+		path, ok := g.BFS(ui.Position{X: 0, Y: 0}, at(2, 0))
+
+		require.True(t, ok)
+		require.Equal(t, []ui.Position{{X: 1, Y: 0}, {X: 2, Y: 0}}, path)
+	})
+
+	t.Run("goes around blocked cells", func(t *testing.T) {
+		g := New(ui.Position{}, 3, 3)
+		g.Block(ui.Position{X: 1, Y: 0}, ui.Position{X: 1, Y: 1})
+
+		path, ok := g.BFS(ui.Position{X: 0, Y: 0}, at(2, 0))
+
+		require.True(t, ok)
+		require.Len(t, path, 6)
+		require.Equal(t, ui.Position{X: 1, Y: 2}, path[2])
+	})
+
+	t.Run("starts from a blocked cell", func(t *testing.T) {
+		g := New(ui.Position{}, 3, 1)
+		g.Block(ui.Position{X: 0, Y: 0})
+
+		path, ok := g.BFS(ui.Position{X: 0, Y: 0}, at(2, 0))
+
+		require.True(t, ok)
+		require.Len(t, path, 2)
+	})
+
+	t.Run("takes the short way round when the grid wraps", func(t *testing.T) {
+		g := New(ui.Position{}, 10, 1)
+		g.SetWrap(true)
+
+		path, ok := g.BFS(ui.Position{X: 1, Y: 0}, at(9, 0))
+
+		require.True(t, ok)
+		require.Equal(t, []ui.Position{{X: 0, Y: 0}, {X: 9, Y: 0}}, path)
+	})
+
+	t.Run("fails when the goal can't be reached", func(t *testing.T) {
+		g := New(ui.Position{}, 3, 3)
+		g.Block(ui.Position{X: 1, Y: 0}, ui.Position{X: 1, Y: 1}, ui.Position{X: 1, Y: 2})
+
+		_, ok := g.BFS(ui.Position{X: 0, Y: 0}, at(2, 0))
+
+		require.False(t, ok)
+	})
+}
+
+func Test_AStar(t *testing.T) {
+	t.Run("finds a shortest path around blocked cells", func(t *testing.T) {
+		g := New(ui.Position{X: 1, Y: 1}, 5, 5)
+		g.Block(ui.Position{X: 3, Y: 1}, ui.Position{X: 3, Y: 2}, ui.Position{X: 3, Y: 3})
+
+		path, ok := g.AStar(ui.Position{X: 1, Y: 1}, ui.Position{X: 5, Y: 1})
+
+		require.True(t, ok)
+		require.Len(t, path, 10)
+		require.Equal(t, ui.Position{X: 5, Y: 1}, path[len(path)-1])
+		for _, c := range path {
+			require.False(t, g.Blocked(c))
+		}
+	})
+
+	t.Run("matches the length of the BFS path", func(t *testing.T) {
+		g := New(ui.Position{}, 12, 9)
+		g.Block(ui.Position{X: 4, Y: 0}, ui.Position{X: 4, Y: 1}, ui.Position{X: 4, Y: 2}, ui.Position{X: 4, Y: 3},
+			ui.Position{X: 8, Y: 8}, ui.Position{X: 8, Y: 7}, ui.Position{X: 8, Y: 6}, ui.Position{X: 8, Y: 5})
+		start, goal := ui.Position{X: 0, Y: 0}, ui.Position{X: 11, Y: 8}
+
+		bfs, _ := g.BFS(start, at(goal.X, goal.Y))
+		astar, ok := g.AStar(start, goal)
+
+		require.True(t, ok)
+		require.Len(t, astar, len(bfs))
+	})
+
+	t.Run("wraps around the edges", func(t *testing.T) {
+		g := New(ui.Position{}, 10, 10)
+		g.SetWrap(true)
+
+		path, ok := g.AStar(ui.Position{X: 0, Y: 0}, ui.Position{X: 9, Y: 9})
+
+		require.True(t, ok)
+		require.Len(t, path, 2)
+	})
+
+	t.Run("fails when the goal is blocked or unreachable", func(t *testing.T) {
+		g := New(ui.Position{}, 3, 3)
+		g.Block(ui.Position{X: 1, Y: 0}, ui.Position{X: 1, Y: 1}, ui.Position{X: 1, Y: 2})
+
+		_, ok := g.AStar(ui.Position{X: 0, Y: 0}, ui.Position{X: 2, Y: 0})
+		require.False(t, ok)
+
+		_, ok = g.AStar(ui.Position{X: 0, Y: 0}, ui.Position{X: 1, Y: 0})
+		require.False(t, ok)
+	})
+}
+
+func Test_Distance(t *testing.T) {
+	g := New(ui.Position{}, 10, 10)
+	require.Equal(t, 14, g.Distance(ui.Position{X: 1, Y: 1}, ui.Position{X: 8, Y: 8}))
+
+	g.SetWrap(true)
+	require.Equal(t, 6, g.Distance(ui.Position{X: 1, Y: 1}, ui.Position{X: 8, Y: 8}))
+}
+
+func Test_FloodFill(t *testing.T) {
+	t.Run("counts every cell of an empty grid", func(t *testing.T) {
+		require.Equal(t, 20, New(ui.Position{}, 5, 4).FloodFill(ui.Position{X: 2, Y: 2}))
+	})
+
+	t.Run("stops at blocked cells", func(t *testing.T) {
+		g := New(ui.Position{}, 5, 4)
+		g.Block(ui.Position{X: 2, Y: 0}, ui.Position{X: 2, Y: 1}, ui.Position{X: 2, Y: 2}, ui.Position{X: 2, Y: 3})
+
+		require.Equal(t, 8, g.FloodFill(ui.Position{X: 0, Y: 0}))
+		require.Equal(t, 8, g.FloodFill(ui.Position{X: 4, Y: 3}))
+	})
+
+	t.Run("counts a blocked start", func(t *testing.T) {
+		g := New(ui.Position{}, 3, 1)
+		g.Block(ui.Position{X: 0, Y: 0})
+
+		require.Equal(t, 3, g.FloodFill(ui.Position{X: 0, Y: 0}))
+	})
+
+	t.Run("is zero outside the grid", func(t *testing.T) {
+		require.Zero(t, New(ui.Position{}, 3, 3).FloodFill(ui.Position{X: 5, Y: 5}))
+	})
+}
+
+func Test_Reachable(t *testing.T) {
+	t.Run("returns the cells on the same side of a wall", func(t *testing.T) {
+		g := New(ui.Position{}, 3, 2)
+		g.Block(ui.Position{X: 1, Y: 0}, ui.Position{X: 1, Y: 1})
+
+		require.ElementsMatch(t, []ui.Position{{X: 2, Y: 0}, {X: 2, Y: 1}}, g.Reachable(ui.Position{X: 2, Y: 1}))
+	})
+
+	t.Run("is empty outside the grid", func(t *testing.T) {
+		require.Empty(t, New(ui.Position{}, 3, 3).Reachable(ui.Position{X: 5, Y: 5}))
+	})
+}
+
+func Test_Free(t *testing.T) {
+	g := New(ui.Position{X: 1, Y: 1}, 2, 2)
+	g.Block(ui.Position{X: 2, Y: 1})
+
+	require.Equal(t, []ui.Position{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 2}}, g.Free())
+}
+
+func Test_HamiltonianCycle(t *testing.T) {
+	sizes := []struct{ width, height int }{{2, 2}, {4, 3}, {3, 4}, {5, 6}, {6, 5}, {39, 38}, {38, 39}}
+	for _, size := range sizes {
+		t.Run(fmt.Sprintf("visits every cell of a %dx%d grid once", size.width, size.height), func(t *testing.T) {
+			topLeft := ui.Position{X: 3, Y: 2}
+			g := New(topLeft, size.width, size.height)
+
+			cycle, err := g.HamiltonianCycle()
+
+			require.NoError(t, err)
+			require.Len(t, cycle, size.width*size.height)
+			require.Equal(t, topLeft, cycle[0])
+			seen := make(map[ui.Position]struct{})
+			for i, c := range cycle {
+				require.True(t, g.Contains(c))
+				seen[c] = struct{}{}
+				next := cycle[(i+1)%len(cycle)]
+				require.Equal(t, 1, g.Distance(c, next), "%v and %v aren't neighbours", c, next)
+			}
+			require.Len(t, seen, len(cycle))
+		})
+	}
+
+	for _, size := range []struct{ width, height int }{{3, 3}, {1, 4}, {4, 1}, {39, 39}} {
+		t.Run(fmt.Sprintf("fails for a %dx%d grid", size.width, size.height), func(t *testing.T) {
+			_, err := New(ui.Position{}, size.width, size.height).HamiltonianCycle()
+
+			require.ErrorIs(t, err, ErrNoHamiltonianCycle)
+		})
+	}
+}
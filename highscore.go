@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"snake/ui"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxHighScores is how many entries the high-score table keeps.
+	MaxHighScores = 10
+	// MaxNameLength is the longest name that can be entered for a high score.
+	MaxNameLength = 10
+	// AnonymousName is recorded when a high score is submitted without a name.
+	AnonymousName    = "Anonymous"
+	NewHighScoreText = "New High Score: %d"
+
+	highScoresFile = "highscores.json"
+)
+
+// HighScore is an entry in the high-score table.
+type HighScore struct {
+	Name     string        `json:"name"`
+	Score    uint          `json:"score"`
+	Length   int           `json:"length"`
+	Duration time.Duration `json:"duration"`
+	Mode     string        `json:"mode"`
+	Date     time.Time     `json:"date"`
+}
+
+// HighScores is the table of the best scores, highest first.
+type HighScores struct {
+	Entries []HighScore `json:"entries"`
+}
+
+// qualifies reports whether a score would make it into the table.
+func (h *HighScores) qualifies(score uint) bool {
+	if score == 0 {
+		return false
+	}
+	return len(h.Entries) < MaxHighScores || score > h.Entries[len(h.Entries)-1].Score
+}
+
+// add puts an entry into the table below any equal scores, returning where it went, or -1
+// if it didn't make the table.
+func (h *HighScores) add(entry HighScore) int {
+	if !h.qualifies(entry.Score) {
+		return -1
+	}
+	i := slices.IndexFunc(h.Entries, func(e HighScore) bool { return e.Score < entry.Score })
+	if i < 0 {
+		i = len(h.Entries)
+	}
+	h.Entries = slices.Insert(h.Entries, i, entry)
+	h.Entries = h.Entries[:min(len(h.Entries), MaxHighScores)]
+	return i
+}
+
+// Save writes the table to a file, creating its directory if needed.
+func (h *HighScores) Save(filename string) error {
+	data, err := json.MarshalIndent(h, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err = os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+// LoadHighScores loads the table from a file. A missing file means no scores yet.
+func LoadHighScores(filename string) (*HighScores, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &HighScores{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open: %w", err)
+	}
+	var ret HighScores
+	if err = json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// DefaultHighScoresFile returns where the high-score table is kept in the user's config
+// directory, beside the campaign progress.
+func DefaultHighScoresFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, progressDir, highScoresFile), nil
+}
+
+// scoreTable is the high-score table of a game, along with the views that show it.
+type scoreTable struct {
+	scores *HighScores
+	file   string
+	entry  *ui.NameEntryView
+	view   *ui.HighScoresView
+	// pending is the score waiting for the player to enter their name.
+	pending HighScore
+	// now returns the date recorded with a new score.
+	now func() time.Time
+	// saveErr holds the last error from saving the table, so it can be reported on exit.
+	saveErr error
+}
+
+// resize lays out the name entry and the table for a board of the given size.
+func (t *scoreTable) resize(width, height int) {
+	t.entry.Resize(width, height)
+	t.view.Resize(width, height)
+}
+
+// setHighScores keeps a high-score table for single-player games, saving it to file as
+// scores are added, unless the file is empty.
+func (g *game) setHighScores(scores *HighScores, file string) {
+	g.highScores = &scoreTable{
+		scores: scores,
+		file:   file,
+		entry:  ui.NewNameEntryView(MaxNameLength),
+		view:   ui.NewHighScoresView(),
+		now:    time.Now,
+	}
+	g.highScores.entry.Field().SetOnSubmit(g.submitHighScore)
+	g.highScores.resize(g.gameBoard.Width(), g.gameBoard.Height())
+	g.AddView(ui.NameEntryViewName, g.highScores.entry)
+	g.AddView(ui.HighScoresViewName, g.highScores.view)
+	g.mainMenu.highScores.SetDisabled(false)
+}
+
+// afterGame returns the state that follows a finished game, which asks for the player's name
+// when they've set a high score.
+func (g *game) afterGame() state {
+	t := g.highScores
+	if t == nil || g.twoPlayer() || g.gameBoard.demo != nil || !t.scores.qualifies(g.score) {
+		return g.menu()
+	}
+	t.pending = HighScore{
+		Score:    g.score,
+		Length:   g.gameBoard.snake.Length(),
+		Duration: g.playTime,
+		Mode:     g.mode(),
+		Date:     t.now(),
+	}
+	t.entry.SetTitle(fmt.Sprintf(NewHighScoreText, g.score))
+	t.entry.Field().SetText("")
+	_ = g.SwitchView(ui.NameEntryViewName)
+	return new(nameEntryState)
+}
+
+// submitHighScore records the pending score under the given name and shows the table.
+func (g *game) submitHighScore(name string) {
+	t := g.highScores
+	if _, ok := g.currentState.(*nameEntryState); !ok {
+		return
+	}
+	if t.pending.Name = strings.TrimSpace(name); t.pending.Name == "" {
+		t.pending.Name = AnonymousName
+	}
+	rank := t.scores.add(t.pending)
+	if t.file != "" {
+		t.saveErr = t.scores.Save(t.file)
+	}
+	g.showHighScores(rank)
+	g.currentState = new(highScoresState)
+}
+
+// showHighScores lists the table, highlighting the entry at index highlight.
+func (g *game) showHighScores(highlight int) {
+	t := g.highScores
+	rows := make([]ui.HighScoreRow, len(t.scores.Entries))
+	for i, e := range t.scores.Entries {
+		rows[i] = ui.HighScoreRow(e)
+	}
+	t.view.SetRows(rows, highlight)
+	_ = g.SwitchView(ui.HighScoresViewName)
+}
+
+// mode describes how the game was played, for the high-score table.
+func (g *game) mode() string {
+	if g.gameBoard.level != nil && g.gameBoard.level.Name != "" {
+		return g.gameBoard.level.Name
+	}
+	return g.gameBoard.wallMode.String()
+}
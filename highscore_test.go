@@ -0,0 +1,189 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"snake/ui"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HighScores(t *testing.T) {
+	// full returns a full table with scores of 1000, 900 and so on.
+	full := func() *HighScores {
+		var ret HighScores
+		for i := range MaxHighScores {
+			ret.Entries = append(ret.Entries, HighScore{Name: "P", Score: uint(1000 - 100*i)})
+		}
+		return &ret
+	}
+
+	t.Run("any score above zero qualifies while there's room", func(t *testing.T) {
+		var h HighScores
+		require.False(t, h.qualifies(0))
+		require.True(t, h.qualifies(1))
+	})
+
+	t.Run("a full table needs a score above the lowest", func(t *testing.T) {
+		h := full()
+		require.False(t, h.qualifies(100))
+		require.True(t, h.qualifies(101))
+	})
+
+	t.Run("entries are kept highest first, below equal scores", func(t *testing.T) {
+		var h HighScores
+		h.add(HighScore{Name: "A", Score: 100})
+		h.add(HighScore{Name: "B", Score: 300})
+
+		require.Equal(t, 2, h.add(HighScore{Name: "C", Score: 100}))
+		require.Equal(t, []string{"B", "A", "C"}, []string{h.Entries[0].Name, h.Entries[1].Name, h.Entries[2].Name})
+	})
+
+	t.Run("the lowest entry drops off a full table", func(t *testing.T) {
+		h := full()
+
+		require.Equal(t, 0, h.add(HighScore{Name: "Top", Score: 2000}))
+		require.Len(t, h.Entries, MaxHighScores)
+		require.Equal(t, uint(200), h.Entries[MaxHighScores-1].Score)
+		require.Equal(t, -1, h.add(HighScore{Score: 1}))
+	})
+
+	t.Run("are saved and loaded", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "snake", highScoresFile)
+		date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+		exp := &HighScores{Entries: []HighScore{
+			{Name: "Ann", Score: 1200, Length: 15, Duration: 90 * time.Second, Mode: "wrap", Date: date},
+		}}
+
+		require.NoError(t, exp.Save(file))
+		act, err := LoadHighScores(file)
+
+		require.NoError(t, err)
+		require.Equal(t, exp, act)
+	})
+
+	t.Run("a missing file means no scores", func(t *testing.T) {
+		act, err := LoadHighScores(filepath.Join(t.TempDir(), highScoresFile))
+
+		require.NoError(t, err)
+		require.Empty(t, act.Entries)
+	})
+
+	t.Run("a corrupt file is an error", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), highScoresFile)
+		require.NoError(t, os.WriteFile(file, []byte("{"), 0o644))
+
+		_, err := LoadHighScores(file)
+
+		require.Error(t, err)
+	})
+}
+
+func Test_HighScoreEntry(t *testing.T) {
+	var g *game
+	var file string
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	// setup ends a single-player game with the given score.
+	setup := func(t *testing.T, cfg *Config, score uint) {
+		file = filepath.Join(t.TempDir(), highScoresFile)
+		g = newSnakeGame(cfg, 60, 30)
+		g.setHighScores(&HighScores{}, file)
+		g.highScores.now = func() time.Time { return date }
+		g.currentState.handle(g, StartGame)
+		g.score, g.remainingLives = score, 0
+		g.playTime = 42 * time.Second
+		g.currentState = &gameOverState{delay: time.Millisecond}
+		g.Update(TickDuration)
+	}
+	typeText := func(text string) {
+		for _, ch := range text {
+			g.Handle(keyPress(tcell.KeyRune, ch))
+		}
+	}
+
+	t.Run("a qualifying score asks for a name", func(t *testing.T) {
+		setup(t, &Config{}, 500)
+
+		require.IsType(t, new(nameEntryState), g.currentState)
+		require.Equal(t, ui.NameEntryViewName, g.ActiveViewName())
+	})
+
+	t.Run("typing a name doesn't quit or steer", func(t *testing.T) {
+		setup(t, &Config{}, 500)
+
+		typeText("quip")
+
+		require.False(t, g.Finished())
+		require.Equal(t, "quip", g.highScores.entry.Field().Text())
+	})
+
+	t.Run("the entry is saved and the table shown", func(t *testing.T) {
+		setup(t, &Config{wallMode: WrapWalls}, 500)
+
+		typeText("Bob")
+		g.Handle(keyPress(tcell.KeyEnter, 0))
+
+		require.IsType(t, new(highScoresState), g.currentState)
+		require.Equal(t, ui.HighScoresViewName, g.ActiveViewName())
+		exp := HighScore{Name: "Bob", Score: 500, Length: g.gameBoard.snake.Length(), Duration: 42 * time.Second, Mode: "wrap", Date: date}
+		require.Equal(t, []HighScore{exp}, g.highScores.scores.Entries)
+		saved, err := LoadHighScores(file)
+		require.NoError(t, err)
+		require.Equal(t, g.highScores.scores, saved)
+	})
+
+	t.Run("an empty name is anonymous", func(t *testing.T) {
+		setup(t, &Config{}, 500)
+
+		g.Handle(keyPress(tcell.KeyEnter, 0))
+
+		require.Equal(t, AnonymousName, g.highScores.scores.Entries[0].Name)
+	})
+
+	t.Run("enter returns from the table to the menu", func(t *testing.T) {
+		setup(t, &Config{}, 500)
+		g.Handle(keyPress(tcell.KeyEnter, 0))
+
+		g.Handle(keyPress(tcell.KeyEnter, 0))
+
+		require.IsType(t, new(menuState), g.currentState)
+		require.Equal(t, mainMenuViewName, g.ActiveViewName())
+	})
+
+	t.Run("escape records the score without a name", func(t *testing.T) {
+		setup(t, &Config{}, 500)
+		typeText("Bob")
+
+		g.Handle(keyPress(tcell.KeyEscape, 0))
+
+		require.IsType(t, new(highScoresState), g.currentState)
+		require.Equal(t, ui.HighScoresViewName, g.ActiveViewName())
+		require.Len(t, g.highScores.scores.Entries, 1)
+		require.Equal(t, AnonymousName, g.highScores.scores.Entries[0].Name)
+		require.False(t, g.Finished())
+	})
+
+	t.Run("ctrl-c still quits", func(t *testing.T) {
+		setup(t, &Config{}, 500)
+
+		g.Handle(keyPress(tcell.KeyCtrlC, 0))
+
+		require.True(t, g.Finished())
+	})
+
+	t.Run("a score that doesn't qualify goes back to the menu", func(t *testing.T) {
+		setup(t, &Config{}, 0)
+
+		require.IsType(t, new(menuState), g.currentState)
+	})
+
+	t.Run("two-player games aren't recorded", func(t *testing.T) {
+		setup(t, &Config{players: 2}, 500)
+
+		require.IsType(t, new(menuState), g.currentState)
+	})
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"snake/ui"
+	"strings"
+)
+
+const (
+	levelWallTile  = '#'
+	levelFloorTile = '.'
+	levelStartTile = 'S'
+
+	levelNameKey      = "name"
+	levelDirectionKey = "direction"
+
+	// LevelsDir is where levels are looked up when a level isn't given as a path.
+	LevelsDir      = "levels"
+	levelExtension = ".txt"
+)
+
+var ErrLevelNotFound = errors.New("level not found")
+
+// Level is a board layout with static walls. In a level file the layout is drawn as a grid,
+// where '#' is a wall, '.' is floor and 'S' is where the head of the snake starts. The grid
+// can be preceded by "key: value" lines that name the level and set the direction the
+// snake starts in, for example:
+//
+//	name: Box
+//	direction: up
+//	#.........#
+//	#....S....#
+//	#.........#
+//
+// The grid only covers the playing area, the board's border is added around it.
+type Level struct {
+	Name      string
+	Direction direction
+	width     int
+	height    int
+	walls     []ui.Position
+	start     ui.Position
+}
+
+// Width returns the number of columns in the playing area.
+func (l *Level) Width() int {
+	return l.width
+}
+
+// Height returns the number of rows in the playing area.
+func (l *Level) Height() int {
+	return l.height
+}
+
+// fits checks that a snake of the given length can be laid out behind the start without
+// running into a wall or off the level.
+func (l *Level) fits(length int) error {
+	dx, dy := l.Direction.offset()
+	for i := range length {
+		p := ui.Position{X: l.start.X - i*dx, Y: l.start.Y - i*dy}
+		if p.X < 0 || p.X >= l.width || p.Y < 0 || p.Y >= l.height || slices.Contains(l.walls, p) {
+			return fmt.Errorf("level %q has no room for a snake of length %d", l.Name, length)
+		}
+	}
+	return nil
+}
+
+// MarshalText encodes the level in the level file format.
+func (l *Level) MarshalText() ([]byte, error) {
+	grid := make([][]byte, l.height)
+	for y := range grid {
+		grid[y] = bytes.Repeat([]byte{levelFloorTile}, l.width)
+	}
+	for _, w := range l.walls {
+		grid[w.Y][w.X] = levelWallTile
+	}
+	grid[l.start.Y][l.start.X] = levelStartTile
+
+	var buf bytes.Buffer
+	if l.Name != "" {
+		_, _ = fmt.Fprintf(&buf, "%s: %s\n", levelNameKey, l.Name)
+	}
+	_, _ = fmt.Fprintf(&buf, "%s: %s\n", levelDirectionKey, l.Direction)
+	for _, row := range grid {
+		buf.Write(row)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText parses a level in the level file format.
+func (l *Level) UnmarshalText(text []byte) error {
+	ret := Level{Direction: startingDir}
+	foundStart := false
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok && ret.height == 0 {
+			if err := ret.setProperty(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+				return fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			continue
+		}
+
+		if ret.height == 0 {
+			ret.width = len(line)
+		} else if len(line) != ret.width {
+			return fmt.Errorf("line %d: expected %d tiles, found %d", lineNo, ret.width, len(line))
+		}
+		for x, tile := range []byte(line) {
+			pos := ui.Position{X: x, Y: ret.height}
+			switch tile {
+			case levelWallTile:
+				ret.walls = append(ret.walls, pos)
+			case levelFloorTile:
+			case levelStartTile:
+				if foundStart {
+					return fmt.Errorf("line %d: more than one snake start", lineNo)
+				}
+				foundStart = true
+				ret.start = pos
+			default:
+				return fmt.Errorf("line %d: unrecognized tile %q", lineNo, tile)
+			}
+		}
+		ret.height += 1
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if ret.height == 0 {
+		return errors.New("level has no tiles")
+	}
+	if !foundStart {
+		return errors.New("level has no snake start")
+	}
+	if ret.width+2 < minWidth || ret.height+levelBorderRows < minHeight {
+		return fmt.Errorf("level is %dx%d, but must be at least %dx%d",
+			ret.width, ret.height, minWidth-2, minHeight-levelBorderRows)
+	}
+	*l = ret
+	return nil
+}
+
+func (l *Level) setProperty(key string, value string) error {
+	switch key {
+	case levelNameKey:
+		l.Name = value
+	case levelDirectionKey:
+		d, err := parseDirection(value)
+		if err != nil {
+			return err
+		}
+		l.Direction = d
+	default:
+		return fmt.Errorf("unrecognized property %q", key)
+	}
+	return nil
+}
+
+// levelBorderRows is the number of rows a board adds around the playing area for the
+// border and the HUD.
+const levelBorderRows = 6
+
+// LoadLevel loads a level from a file.
+func LoadLevel(filename string) (*Level, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open: %w", err)
+	}
+	var ret Level
+	if err = ret.UnmarshalText(data); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return &ret, nil
+}
+
+// FindLevel returns the file for a level. The name is either a path to a level file, or
+// the name of a level in the levels directory, with or without its extension.
+func FindLevel(name string) (string, error) {
+	candidates := []string{
+		name,
+		filepath.Join(LevelsDir, name),
+		filepath.Join(LevelsDir, name+levelExtension),
+	}
+	for _, c := range candidates {
+		if info, err := os.Stat(c); err == nil && !info.IsDir() {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrLevelNotFound, name)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"snake/ui"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testLevel returns a level file with an 18x9 playing area, the smallest allowed. There's a
+// wall in the middle of the second row and the snake starts in the middle of the board.
+func testLevel(header ...string) string {
+	rows := make([]string, 9)
+	for y := range rows {
+		rows[y] = strings.Repeat(".", 18)
+	}
+	rows[1] = "........##........"
+	rows[4] = "........S........."
+	return strings.Join(append(header, rows...), "\n") + "\n"
+}
+
+func loadTestLevel(t *testing.T, header ...string) *Level {
+	var ret Level
+	require.NoError(t, ret.UnmarshalText([]byte(testLevel(header...))))
+	return &ret
+}
+
+func Test_Level(t *testing.T) {
+	t.Run("parses tiles", func(t *testing.T) {
+		l := loadTestLevel(t)
+
+		require.Equal(t, 18, l.Width())
+		require.Equal(t, 9, l.Height())
+		require.Equal(t, []ui.Position{{X: 8, Y: 1}, {X: 9, Y: 1}}, l.walls)
+		require.Equal(t, ui.Position{X: 8, Y: 4}, l.start)
+	})
+
+	t.Run("starts moving right by default", func(t *testing.T) {
+		require.Equal(t, right, loadTestLevel(t).Direction)
+	})
+
+	t.Run("parses properties", func(t *testing.T) {
+		l := loadTestLevel(t, "name: Test", "direction: up")
+
+		require.Equal(t, "Test", l.Name)
+		require.Equal(t, up, l.Direction)
+	})
+
+	t.Run("round trips through text", func(t *testing.T) {
+		l := loadTestLevel(t, "name: Test", "direction: down")
+
+		text, err := l.MarshalText()
+		require.NoError(t, err)
+		require.Equal(t, testLevel("name: Test", "direction: down"), string(text))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			level string
+		}{
+			{name: "unknown tile", level: strings.Replace(testLevel(), "##", "#x", 1)},
+			{name: "ragged rows", level: strings.Replace(testLevel(), "##", "###", 1)},
+			{name: "no start", level: strings.Replace(testLevel(), "S", ".", 1)},
+			{name: "two starts", level: strings.Replace(testLevel(), "##", "SS", 1)},
+			{name: "unknown property", level: testLevel("colour: red")},
+			{name: "unknown direction", level: testLevel("direction: sideways")},
+			{name: "too small", level: "S.\n..\n"},
+			{name: "empty", level: "name: Empty\n"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var l Level
+				require.Error(t, l.UnmarshalText([]byte(tt.level)))
+			})
+		}
+	})
+
+	t.Run("fits", func(t *testing.T) {
+		t.Run("snake behind the start", func(t *testing.T) {
+			require.NoError(t, loadTestLevel(t).fits(9))
+		})
+
+		t.Run("snake running off the level", func(t *testing.T) {
+			require.Error(t, loadTestLevel(t).fits(10))
+		})
+
+		t.Run("snake running into a wall", func(t *testing.T) {
+			l := loadTestLevel(t, "direction: down")
+
+			require.NoError(t, l.fits(2))
+			require.Error(t, l.fits(4))
+		})
+	})
+}
+
+func Test_FindLevel(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	require.NoError(t, os.Mkdir(LevelsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(LevelsDir, "box.txt"), []byte(testLevel()), 0o644))
+	require.NoError(t, os.WriteFile("custom.lvl", []byte(testLevel()), 0o644))
+
+	t.Run("finds a path", func(t *testing.T) {
+		filename, err := FindLevel("custom.lvl")
+
+		require.NoError(t, err)
+		require.Equal(t, "custom.lvl", filename)
+	})
+
+	t.Run("finds a level in the levels directory by name", func(t *testing.T) {
+		filename, err := FindLevel("box")
+
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(LevelsDir, "box.txt"), filename)
+	})
+
+	t.Run("missing level", func(t *testing.T) {
+		_, err := FindLevel("missing")
+
+		require.ErrorIs(t, err, ErrLevelNotFound)
+	})
+
+	t.Run("loads a found level", func(t *testing.T) {
+		l, err := LoadLevel(filepath.Join(LevelsDir, "box.txt"))
+
+		require.NoError(t, err)
+		require.Equal(t, ui.Position{X: 8, Y: 4}, l.start)
+	})
+}
+
+func Test_BundledLevels(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(LevelsDir, "*"+levelExtension))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, f := range files {
+		t.Run(filepath.Base(f), func(t *testing.T) {
+			l, err := LoadLevel(f)
+
+			require.NoError(t, err)
+			require.NoError(t, l.fits(DefaultStartingLength))
+		})
+	}
+}
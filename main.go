@@ -1,12 +1,71 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+func usage() {
+	out := flag.CommandLine.Output()
+	_, _ = fmt.Fprintf(out, "Usage:\n  %[1]s [flags]\n  %[1]s replay <file>\n  %[1]s config validate [file]\n\n", os.Args[0])
+	_, _ = fmt.Fprintf(out, "Settings are read from the first %s found in:\n", ConfigFile)
+	for _, p := range ConfigPaths() {
+		_, _ = fmt.Fprintf(out, "  %s\n", p)
+	}
+	_, _ = fmt.Fprintf(out, "falling back to the defaults if there's none. Flags override %s* environment\n", envPrefix)
+	_, _ = fmt.Fprintf(out, "variables, which override the file.\n\nFlags:\n")
+	flag.PrintDefaults()
+}
+
 func main() {
+	record := flag.String("record", "", "write a replay of the last game played to this file on exit")
+	levelName := flag.String("level", "", "play a level, either a path to a level file or the name of one in the "+LevelsDir+" directory")
+	campaignFile := flag.String("campaign", "", "play a campaign of levels from this file, for example "+filepath.Join(LevelsDir, "campaign.json"))
+	demo := flag.Bool("demo", false, "watch the game play itself until the snake fills the board")
+	overrides := NewConfigOverrides()
+	overrides.RegisterFlags(flag.CommandLine)
+	flag.Usage = usage
+	flag.Parse()
+	if err := overrides.ReadEnv(os.LookupEnv); err != nil {
+		log.Fatalf("invalid environment variable: %v", err)
+	}
+	if *levelName != "" && *campaignFile != "" {
+		log.Fatal("a level and a campaign can't be played at the same time")
+	}
+
+	var replay *Replay
+	switch flag.Arg(0) {
+	case "":
+	case "replay":
+		if flag.NArg() != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		var err error
+		if replay, err = LoadReplay(flag.Arg(1)); err != nil {
+			log.Fatalf("failed to load replay: %v", err)
+		}
+	case "config":
+		if flag.NArg() < 2 || flag.NArg() > 3 || flag.Arg(1) != "validate" {
+			flag.Usage()
+			os.Exit(2)
+		}
+		file := FindConfig(ConfigPaths())
+		if flag.NArg() == 3 {
+			file = flag.Arg(2)
+		}
+		os.Exit(validateConfig(file))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	scn, err := tcell.NewScreen()
 	if err != nil {
 		log.Fatalf("failed to get screen: %v", err)
@@ -14,14 +73,154 @@ func main() {
 	if err = scn.Init(); err != nil {
 		log.Fatalf("failed to init screen: %v", err)
 	}
-	cfg, err := LoadConfig("config.json")
-	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+
+	if replay != nil {
+		viewer, err := newReplayViewer(replay)
+		if err != nil {
+			scn.Fini()
+			log.Fatalf("failed to load replay: %v", err)
+		}
+		err = RunGame(viewer, scn, SystemClock())
+		scn.Fini()
+		if err != nil {
+			log.Fatalf("error while running replay: %v", err)
+		}
+		return
+	}
+
+	cfg := &Config{}
+	configFile := FindConfig(ConfigPaths())
+	if configFile != "" {
+		if cfg, err = LoadConfig(configFile); err != nil {
+			scn.Fini()
+			log.Fatalf("failed to load config %s: %v", configFile, err)
+		}
+	} else {
+		// the settings screen creates the file where it's looked for first
+		configFile = ConfigPaths()[0]
+	}
+	saved := *cfg
+	overrides.Apply(cfg)
+	if err = cfg.Validate(); err != nil {
+		scn.Fini()
+		log.Fatalf("invalid settings: %v", err)
+	}
+	if (cfg.Players() > 1 || cfg.Bots() > 0) && (*levelName != "" || *campaignFile != "") {
+		scn.Fini()
+		log.Fatal("levels and campaigns are single player only")
+	}
+	if *demo && (*levelName != "" || *campaignFile != "") {
+		scn.Fini()
+		log.Fatal("the demo can't be combined with a level or a campaign")
+	}
+	var level *Level
+	if *levelName != "" {
+		if level, err = findAndLoadLevel(*levelName); err != nil {
+			scn.Fini()
+			log.Fatalf("failed to load level: %v", err)
+		}
+	}
+	var campaign *Campaign
+	var progress *CampaignProgress
+	var progressFile string
+	if *campaignFile != "" {
+		if campaign, err = LoadCampaign(*campaignFile); err != nil {
+			scn.Fini()
+			log.Fatalf("failed to load campaign: %v", err)
+		}
+		if progressFile, err = DefaultProgressFile(); err != nil {
+			progress = &CampaignProgress{}
+		} else if progress, err = LoadCampaignProgress(progressFile); err != nil {
+			scn.Fini()
+			log.Fatalf("failed to load campaign progress: %v", err)
+		}
 	}
 	width, height := scn.Size()
-	err = RunGame(newSnakeGame(cfg, width, height), scn)
+	if level == nil && campaign == nil {
+		if err = cfg.ValidateScreenSize(width, height); err != nil {
+			scn.Fini()
+			log.Fatalf("invalid board size: %v", err)
+		}
+	}
+	var g *game
+	if *demo {
+		g = newDemoGame(*cfg, width, height)
+	} else {
+		g = newSnakeGame(cfg, width, height)
+	}
+	if level != nil {
+		if err = g.setLevel(level); err != nil {
+			scn.Fini()
+			log.Fatalf("invalid level: %v", err)
+		}
+		if bw, bh := g.gameBoard.Width(), g.gameBoard.Height(); width < bw || height < bh {
+			scn.Fini()
+			log.Fatalf("level needs a %dx%d screen, but the screen is %dx%d", bw, bh, width, height)
+		}
+	}
+	if campaign != nil {
+		if err = g.setCampaign(campaign, progress, progressFile); err != nil {
+			scn.Fini()
+			log.Fatalf("invalid campaign: %v", err)
+		}
+	}
+	if !*demo {
+		scores := &HighScores{}
+		scoresFile, err := DefaultHighScoresFile()
+		if err != nil {
+			scoresFile = ""
+		} else if scores, err = LoadHighScores(scoresFile); err != nil {
+			scn.Fini()
+			log.Fatalf("failed to load high scores: %v", err)
+		}
+		g.setHighScores(scores, scoresFile)
+		g.setSettings(saved, configFile, overrides.Apply)
+	}
+	err = RunGame(g, scn, SystemClock())
 	scn.Fini()
 	if err != nil {
 		log.Fatalf("error while running game: %v", err)
 	}
+	if g.campaign != nil && g.campaign.saveErr != nil {
+		log.Printf("failed to save campaign progress: %v", g.campaign.saveErr)
+	}
+	if g.highScores != nil && g.highScores.saveErr != nil {
+		log.Printf("failed to save high scores: %v", g.highScores.saveErr)
+	}
+	if *record != "" && g.recording != nil {
+		if err = g.recording.Save(*record); err != nil {
+			log.Fatalf("failed to save replay: %v", err)
+		}
+	}
+}
+
+// validateConfig prints every problem with a config file, one per line, and returns the exit
+// code of the config validate command.
+func validateConfig(filename string) int {
+	if filename == "" {
+		fmt.Printf("no %s found, the defaults are used\n", ConfigFile)
+		return 0
+	}
+	err := CheckConfigFile(filename)
+	if err == nil {
+		fmt.Printf("%s is valid\n", filename)
+		return 0
+	}
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filename, err)
+		return 1
+	}
+	for _, e := range joined.Unwrap() {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filename, e)
+	}
+	return 1
+}
+
+func findAndLoadLevel(name string) (*Level, error) {
+	filename, err := FindLevel(name)
+	if err != nil {
+		return nil, err
+	}
+	return LoadLevel(filename)
 }
@@ -1,33 +1,108 @@
 package main
 
 import (
+	"fmt"
 	"snake/ui"
+
+	"github.com/gdamore/tcell/v2"
 )
 
-type MainMenu struct {
+const (
+	mainMenuViewName = "MainMenu"
+	mainMenuTitle    = "Main Menu"
+	modeMenuTitle    = "Mode"
+	modeItemFormat   = "Mode: %s"
+	maxMenuWidth     = 32
+)
+
+// menuView shows a menu over an empty board. Keys are ignored, since the game steers the menu
+// through its own key bindings, which lets WASD move through it like the arrow keys.
+type menuView struct {
 	*ui.GameBoardRenderer
+	menu *ui.Menu
+	cfg  *Config
+}
+
+func (v *menuView) Handle(tcell.Event) {
+	// do nothing
+}
+
+// Resize fits the menu to a screen of the given size.
+func (v *menuView) Resize(width int, height int) {
+	v.SetSize(v.cfg.BoardSize(width, height))
+	ul, menuWidth, menuHeight := menuLayout(v.GameBoardRenderer)
+	v.menu.SetSize(menuWidth, menuHeight)
+	v.menu.SetPosition(ul)
+}
+
+// menuLayout returns where a menu is placed on the board and its dimensions. The menu may
+// reach down to the bottom of the board, so every entry fits even on the smallest board, and
+// is wide enough for the longest settings on the default board.
+func menuLayout(board *ui.GameBoardRenderer) (ui.Position, int, int) {
+	menuWidth := max((board.Width()/10)*7, min(board.Width()-4, maxMenuWidth))
+	padding := (board.Width() - menuWidth) / 2
+
+	x := board.Left() + padding
+	y := max(board.Height()/4, board.Top()+1)
+
+	return ui.Position{X: x, Y: y}, menuWidth, board.Bottom() - y
 }
 
-func NewMainMenu(ul ui.Position, width int, height int) *MainMenu {
-	boardRenderer := ui.NewGameBoardRenderer(ul, width, height)
+// newMenuView returns an empty menu with the given title, the size of the game's board.
+func newMenuView(g *game, title string) *menuView {
+	boardRenderer := ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, g.gameBoard.Width(), g.gameBoard.Height())
+	ul, menuWidth, menuHeight := menuLayout(boardRenderer)
+	menu := ui.NewMenu(ul, menuWidth, menuHeight, title)
+	_ = boardRenderer.Add(menu)
+	return &menuView{GameBoardRenderer: boardRenderer, menu: menu, cfg: g.cfg}
+}
 
-	menuWidth := (width / 10) * 7
-	padding := (boardRenderer.Width() - menuWidth) / 2
+// MainMenu is shown between games, and lets the player start a game, change the wall mode,
+// change the settings, look at the high scores or quit.
+type MainMenu struct {
+	*menuView
+	mode       *ui.MenuItem
+	settings   *ui.MenuItem
+	highScores *ui.MenuItem
+}
+
+// newMainMenu builds the main menu of a game. The settings and high scores stay disabled
+// until the game has somewhere to keep them.
+func newMainMenu(g *game) *MainMenu {
+	ret := &MainMenu{menuView: newMenuView(g, mainMenuTitle)}
+	menu := ret.menu
 
-	x := boardRenderer.Left() + padding
-	y := boardRenderer.Height() / 4
+	ul, menuWidth, menuHeight := menuLayout(ret.GameBoardRenderer)
+	modes := ui.NewMenu(ul, menuWidth, menuHeight, modeMenuTitle)
+	for _, mode := range wallModes {
+		modes.AddItem(mode.String(), func() {
+			g.setWallMode(mode)
+			menu.Back()
+		})
+	}
 
-	mainMenu := ui.NewMenu(
-		ui.Position{X: x, Y: y},
-		menuWidth, height/2,
-		"Main Menu",
-	)
-	mainMenu.AddEntry("")
-	mainMenu.AddEntry("Enter to Start")
-	mainMenu.AddEntry("SpcBr to Pause")
-	mainMenu.AddEntry("Ctrl-C to Exit")
+	menu.AddItem("Play", g.play)
+	ret.mode = menu.AddSubmenu(fmt.Sprintf(modeItemFormat, g.gameBoard.wallMode), modes)
+	ret.settings = menu.AddItem("Settings", g.showSettings).SetDisabled(true)
+	ret.highScores = menu.AddItem("High Scores", func() {
+		g.showHighScores(-1)
+		g.currentState = new(highScoresState)
+	}).SetDisabled(true)
+	menu.AddItem("Quit", func() { g.finished = true })
+	return ret
+}
 
-	_ = boardRenderer.Add(mainMenu)
+// play starts a new game.
+func (g *game) play() {
+	g.reset()
+	_ = g.SwitchView(gameBoardViewName)
+	g.Manager.HideModal()
+	g.currentState = &playingState{board: g.gameBoard}
+}
 
-	return &MainMenu{boardRenderer}
+// setWallMode changes what happens at the edge of the board in the games that follow.
+func (g *game) setWallMode(mode WallMode) {
+	g.gameBoard.wallMode = mode
+	g.gameBoard.SetMode(mode.String())
+	g.mainMenu.mode.SetText(fmt.Sprintf(modeItemFormat, mode))
 }
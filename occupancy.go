@@ -0,0 +1,222 @@
+package main
+
+import "snake/ui"
+
+// cell is what sits in one cell of the board.
+type cell struct {
+	// owner is the snake with a part of its body in the cell, and segments how many parts
+	// are there, which is more than one just after the snake grows or while a ghost passes
+	// over itself.
+	owner    *snake
+	segments int
+	apple    *apple
+	wall     bool
+	powerUp  bool
+}
+
+// occupancy records what sits in each cell inside the board, so checking a cell doesn't
+// mean searching every snake and apple. Snakes keep it up to date as they move, grow and
+// shrink, and apples as they're eaten and respawn. Anything else that changes the board,
+// like a snake being reset, is noticed the next time the board's occupancy is asked for, and
+// the grid is built again.
+type occupancy struct {
+	topLeft ui.Position
+	width   int
+	height  int
+	cells   []cell
+	// snakeCells counts the cells with a snake in them.
+	snakeCells int
+
+	// the rest is what the grid was built from, checked to see if it's still up to date
+	snakes    map[*snake]ends
+	apples    map[*apple]placed
+	powerUp   placed
+	obstacles int
+}
+
+// ends is enough of a snake to tell if its body has changed other than by the snake moving.
+type ends struct {
+	length int
+	tail   ui.Position
+	head   ui.Position
+}
+
+// placed is where an apple or power-up was, and whether it was on the board.
+type placed struct {
+	pos     ui.Position
+	present bool
+}
+
+// occupied returns what sits in each cell of the board, building the grid again if the
+// board has changed since it was last used.
+func (b *gameBoard) occupied() *occupancy {
+	if b.occupancy == nil || !b.occupancy.matches(b) {
+		b.occupancy = newOccupancy(b)
+	}
+	return b.occupancy
+}
+
+func newOccupancy(b *gameBoard) *occupancy {
+	ret := &occupancy{
+		topLeft:   ui.Position{X: b.Left() + 1, Y: b.Top() + 1},
+		width:     max(b.Right()-b.Left()-1, 0),
+		height:    max(b.Bottom()-b.Top()-1, 0),
+		snakes:    make(map[*snake]ends),
+		apples:    make(map[*apple]placed, len(b.apples)),
+		obstacles: len(b.obstacles),
+	}
+	ret.cells = make([]cell, ret.width*ret.height)
+	for pos := range b.obstacles {
+		if c := ret.at(pos); c != nil {
+			c.wall = true
+		}
+	}
+	b.forEachSnake(func(s *snake) {
+		for _, p := range s.Body {
+			ret.add(s, p)
+		}
+		ret.track(s)
+	})
+	b.apples.ForEach(func(a *apple) {
+		at := appleAt(a)
+		ret.apples[a] = at
+		if c := ret.at(a.Pos); c != nil && at.present {
+			c.apple = a
+		}
+	})
+	ret.powerUp = powerUpAt(&b.powerUp)
+	if c := ret.at(b.powerUp.Pos); c != nil && ret.powerUp.present {
+		c.powerUp = true
+	}
+	return ret
+}
+
+// matches reports whether the grid still describes the board.
+func (o *occupancy) matches(b *gameBoard) bool {
+	if o.topLeft != (ui.Position{X: b.Left() + 1, Y: b.Top() + 1}) ||
+		o.width != b.Right()-b.Left()-1 || o.height != b.Bottom()-b.Top()-1 ||
+		o.obstacles != len(b.obstacles) || o.powerUp != powerUpAt(&b.powerUp) {
+		return false
+	}
+	if len(o.apples) != len(b.apples) {
+		return false
+	}
+	for i := range b.apples {
+		if at, ok := o.apples[&b.apples[i]]; !ok || at != appleAt(&b.apples[i]) {
+			return false
+		}
+	}
+	count, ok := 0, true
+	b.forEachSnake(func(s *snake) {
+		count += 1
+		e, found := o.snakes[s]
+		ok = ok && found && e == endsOf(s)
+	})
+	return ok && count == len(o.snakes)
+}
+
+// get returns what sits in a cell. Cells outside the board are walls.
+func (o *occupancy) get(pos ui.Position) cell {
+	if c := o.at(pos); c != nil {
+		return *c
+	}
+	return cell{wall: true}
+}
+
+// moved records a snake moving its tail to a new head. Like grew and shrank, it has to be
+// called on the grid fetched before the body changed, otherwise the grid is built again
+// from the new body and the change is counted twice.
+func (o *occupancy) moved(s *snake, tail ui.Position, head ui.Position) {
+	o.remove(tail)
+	o.add(s, head)
+	o.track(s)
+}
+
+// grew records a snake adding segments at the given cells.
+func (o *occupancy) grew(s *snake, cells ...ui.Position) {
+	for _, p := range cells {
+		o.add(s, p)
+	}
+	o.track(s)
+}
+
+// shrank records a snake losing the segments at the given cells.
+func (o *occupancy) shrank(s *snake, cells ...ui.Position) {
+	for _, p := range cells {
+		o.remove(p)
+	}
+	o.track(s)
+}
+
+// appleMoved records an apple being eaten, or placed again somewhere else. Like moved, it
+// has to be called on the grid fetched before the apple changed. Apples that aren't on the
+// board yet are left for the grid to find when it's built again.
+func (o *occupancy) appleMoved(a *apple) {
+	was, ok := o.apples[a]
+	if !ok {
+		return
+	}
+	if c := o.at(was.pos); c != nil && was.present && c.apple == a {
+		c.apple = nil
+	}
+	now := appleAt(a)
+	if c := o.at(now.pos); c != nil && now.present {
+		c.apple = a
+	}
+	o.apples[a] = now
+}
+
+// free reports whether nothing sits in a cell.
+func (c cell) free() bool {
+	return !c.wall && c.owner == nil && c.apple == nil && !c.powerUp
+}
+
+func (o *occupancy) add(s *snake, pos ui.Position) {
+	c := o.at(pos)
+	if c == nil {
+		return
+	}
+	if c.segments == 0 {
+		o.snakeCells += 1
+	}
+	c.owner = s
+	c.segments += 1
+}
+
+func (o *occupancy) remove(pos ui.Position) {
+	c := o.at(pos)
+	if c == nil || c.segments == 0 {
+		return
+	}
+	if c.segments -= 1; c.segments == 0 {
+		c.owner = nil
+		o.snakeCells -= 1
+	}
+}
+
+func (o *occupancy) track(s *snake) {
+	o.snakes[s] = endsOf(s)
+}
+
+func (o *occupancy) at(pos ui.Position) *cell {
+	x, y := pos.X-o.topLeft.X, pos.Y-o.topLeft.Y
+	if x < 0 || x >= o.width || y < 0 || y >= o.height {
+		return nil
+	}
+	return &o.cells[y*o.width+x]
+}
+
+func endsOf(s *snake) ends {
+	if len(s.Body) == 0 {
+		return ends{}
+	}
+	return ends{length: len(s.Body), tail: s.Body[0], head: s.head()}
+}
+
+func appleAt(a *apple) placed {
+	return placed{pos: a.Pos, present: a.visible() && !a.eaten}
+}
+
+func powerUpAt(p *powerUp) placed {
+	return placed{pos: p.Pos, present: p.Visible}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"snake/ui"
+	"testing"
+)
+
+// benchmarkLengths are the lengths of snake the occupancy checks are measured with, which
+// should take the same time for each.
+var benchmarkLengths = []int{10, 1000, 10000}
+
+// benchmarkBoard returns a board big enough for the longest snake, with a snake of the given
+// length laid along a cycle through every cell, so it can follow the cycle forever. It also
+// returns the direction to move in from each cell of the cycle.
+func benchmarkBoard(length int) (*game, []ui.Position, map[ui.Position]direction) {
+	cfg := normalApplesConfig()
+	cfg.boardWidth, cfg.boardHeight, cfg.maxNumberOfApples = 130, 130, 1
+	g := newSnakeGame(cfg, 130, 130)
+	b := g.gameBoard
+	gr := b.grid()
+	cycle, err := gr.HamiltonianCycle()
+	if err != nil {
+		panic(err)
+	}
+	next := make(map[ui.Position]direction, len(cycle))
+	for i, c := range cycle {
+		next[c] = towards(gr, c, cycle[(i+1)%len(cycle)], right)
+	}
+	b.snake.Body = append([]ui.Position(nil), cycle[:length]...)
+	b.apples[0].Pos = cycle[len(cycle)-1]
+	b.occupied()
+	return g, cycle, next
+}
+
+func BenchmarkCrashed(b *testing.B) {
+	for _, length := range benchmarkLengths {
+		b.Run(fmt.Sprintf("length %d", length), func(b *testing.B) {
+			g, cycle, _ := benchmarkBoard(length)
+			s, o := g.gameBoard.snake, g.gameBoard.occupied()
+			b.ResetTimer()
+			for i := range b.N {
+				s.crashed(o, cycle[i%length])
+			}
+		})
+	}
+}
+
+func BenchmarkSnakeAt(b *testing.B) {
+	for _, length := range benchmarkLengths {
+		b.Run(fmt.Sprintf("length %d", length), func(b *testing.B) {
+			g, cycle, _ := benchmarkBoard(length)
+			b.ResetTimer()
+			for i := range b.N {
+				g.gameBoard.snakeAt(cycle[i%length], nil)
+			}
+		})
+	}
+}
+
+// BenchmarkEatAndRespawn puts the apple under the snake's head for it to eat, then has it
+// respawn, trimming the snake back to its length between rounds.
+func BenchmarkEatAndRespawn(b *testing.B) {
+	for _, length := range benchmarkLengths {
+		b.Run(fmt.Sprintf("length %d", length), func(b *testing.B) {
+			g, _, _ := benchmarkBoard(length)
+			board, s := g.gameBoard, g.gameBoard.snake
+			a := &board.apples[0]
+			o := board.occupied()
+			b.ResetTimer()
+			for range b.N {
+				b.StopTimer()
+				a.Pos = s.head()
+				o.appleMoved(a)
+				b.StartTimer()
+
+				s.eat(o)
+				board.apples.Update(board, 0)
+
+				b.StopTimer()
+				s.shrink(o, len(s.Body)-length)
+				b.StartTimer()
+			}
+			if board.occupied() != o {
+				b.Fatal("eating and respawning built the grid again")
+			}
+		})
+	}
+}
+
+func BenchmarkMove(b *testing.B) {
+	for _, length := range benchmarkLengths {
+		b.Run(fmt.Sprintf("length %d", length), func(b *testing.B) {
+			g, _, next := benchmarkBoard(length)
+			g.gameBoard.apples = nil
+			s := g.gameBoard.snake
+			b.ResetTimer()
+			for range b.N {
+				s.dir = next[s.head()]
+				s.Update(g.gameBoard, g, s.moveTimer)
+			}
+		})
+	}
+}
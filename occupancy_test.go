@@ -0,0 +1,91 @@
+package main
+
+import (
+	"snake/ui"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Occupancy(t *testing.T) {
+	var g *game
+	var b *gameBoard
+
+	setup := func() {
+		g = newSnakeGame(normalApplesConfig(), minWidth, minHeight)
+		b = g.gameBoard
+	}
+
+	t.Run("records what's in each cell", func(t *testing.T) {
+		setup()
+		wall := ui.Position{X: b.Left() + 1, Y: b.Top() + 1}
+		b.obstacles = map[ui.Position]struct{}{wall: {}}
+		b.powerUp.Visible, b.powerUp.Pos = true, ui.Position{X: b.Left() + 2, Y: b.Top() + 1}
+		o := b.occupied()
+
+		require.True(t, o.get(wall).wall)
+		require.True(t, o.get(b.powerUp.Pos).powerUp)
+		require.Equal(t, &b.apples[0], o.get(b.apples[0].Pos).apple)
+		for _, p := range b.snake.Body {
+			require.Equal(t, b.snake, o.get(p).owner)
+		}
+		require.True(t, o.get(ui.Position{X: b.Left(), Y: b.Top()}).wall, "the border is a wall")
+		require.False(t, o.get(b.freeCells()[0]).wall)
+	})
+
+	t.Run("follows the snake as it moves and grows", func(t *testing.T) {
+		setup()
+		b.apples[0].Pos = ui.Position{X: b.snake.head().X + 1, Y: b.snake.head().Y}
+		tail := b.snake.Body[0]
+
+		simulate(b.snake, g, MoveRight, MoveRight, MoveDown, MoveLeft)
+
+		require.Nil(t, b.occupied().get(tail).owner)
+		require.Equal(t, b.snake, b.occupied().get(b.snake.head()).owner)
+		require.Equal(t, newOccupancy(b), b.occupied())
+	})
+
+	t.Run("counts each cell a snake covers once", func(t *testing.T) {
+		setup()
+		o := b.occupied()
+		n := o.snakeCells
+
+		b.snake.grow(b.occupied())
+		require.Equal(t, n, o.snakeCells)
+
+		simulate(b.snake, g, MoveRight)
+		require.Same(t, o, b.occupied(), "moving shouldn't build the grid again")
+		require.Equal(t, n+1, o.snakeCells)
+		require.Equal(t, b.snake, o.get(b.snake.Body[0]).owner)
+	})
+
+	t.Run("is built again when a snake is reset", func(t *testing.T) {
+		setup()
+		simulate(b.snake, g, MoveDown, MoveDown)
+		o := b.occupied()
+
+		b.snake.ResetTo(b.start())
+
+		require.NotSame(t, o, b.occupied())
+		require.Equal(t, newOccupancy(b), b.occupied())
+	})
+
+	t.Run("follows apples as they're eaten and respawn", func(t *testing.T) {
+		setup()
+		o := b.occupied()
+		a := &b.apples[0]
+		a.Pos = ui.Position{X: b.snake.head().X + 1, Y: b.snake.head().Y}
+		o.appleMoved(a)
+		eatenAt := a.Pos
+
+		simulate(b.snake, g, MoveRight)
+		require.True(t, a.eaten)
+		require.Nil(t, o.get(eatenAt).apple)
+
+		b.apples.Update(b, 0)
+		require.False(t, a.eaten)
+		require.Equal(t, a, o.get(a.Pos).apple)
+		require.Same(t, o, b.occupied(), "eating and respawning shouldn't build the grid again")
+		require.Equal(t, newOccupancy(b), b.occupied())
+	})
+}
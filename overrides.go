@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// envPrefix starts the name of the environment variable of each option, followed by the name
+// of its flag in upper case.
+const envPrefix = "SNAKE_"
+
+// configOption is a setting that can be given as a flag or an environment variable.
+type configOption struct {
+	name  string
+	usage string
+	// parse checks a value of the option, returning what applies it to a configuration.
+	parse func(value string) (func(*Config), error)
+}
+
+func (o configOption) env() string {
+	return envPrefix + strings.ToUpper(o.name)
+}
+
+// intOption is an option holding a whole number of at least lo.
+func intOption(name, usage string, lo int, set func(*Config, int)) configOption {
+	return configOption{
+		name:  name,
+		usage: usage,
+		parse: func(value string) (func(*Config), error) {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%q isn't a whole number", value)
+			}
+			if n < lo {
+				return nil, fmt.Errorf("must be at least %d", lo)
+			}
+			return func(c *Config) { set(c, n) }, nil
+		},
+	}
+}
+
+// configOptions lists the settings that can be overridden, in the order they're applied.
+var configOptions = []configOption{
+	intOption("lives", "number of lives", 1, func(c *Config, lives int) { c.SetNumberOfLives(uint(lives)) }),
+	intOption("apples", "maximum number of apples on the board", 1, (*Config).SetMaxNumberOfApples),
+	intOption("length", "starting length of the snakes", 1, (*Config).SetSnakeStartingLength),
+	intOption("width", "width of the board", 1, (*Config).SetBoardWidth),
+	intOption("height", "height of the board", 1, (*Config).SetBoardHeight),
+	intOption("players", fmt.Sprintf("number of players sharing the keyboard, 1 or %d", MaxPlayers), 1, (*Config).SetPlayers),
+	intOption("bots", "number of computer-controlled snakes", 0, (*Config).SetBots),
+	{
+		name:  "walls",
+		usage: "what happens at the edge of the board: solid, deadly, wrap or bounce",
+		parse: func(value string) (func(*Config), error) {
+			var mode WallMode
+			if err := mode.UnmarshalText([]byte(value)); err != nil {
+				return nil, err
+			}
+			return func(c *Config) { c.SetWallMode(mode) }, nil
+		},
+	},
+	{
+		name:  "seed",
+		usage: "seed for the random number generator, 0 for a random game every time",
+		parse: func(value string) (func(*Config), error) {
+			seed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q isn't a whole number", value)
+			}
+			return func(c *Config) { c.SetSeed(seed) }, nil
+		},
+	},
+}
+
+// ConfigOverrides are settings given as flags or environment variables, which take precedence
+// over the config file. Flags take precedence over environment variables.
+type ConfigOverrides struct {
+	env   map[string]func(*Config)
+	flags map[string]func(*Config)
+}
+
+// RegisterFlags adds a flag for each option that can be overridden.
+func (o *ConfigOverrides) RegisterFlags(fs *flag.FlagSet) {
+	for _, opt := range configOptions {
+		fs.Func(opt.name, opt.usage+", overrides "+opt.env()+" and the config file", func(value string) error {
+			set, err := opt.parse(value)
+			if err != nil {
+				return err
+			}
+			o.flags[opt.name] = set
+			return nil
+		})
+	}
+}
+
+// ReadEnv reads the options from the environment variables found by lookup, reporting every
+// variable that holds a bad value. Empty variables are ignored.
+func (o *ConfigOverrides) ReadEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+	for _, opt := range configOptions {
+		value, ok := lookup(opt.env())
+		if !ok || value == "" {
+			continue
+		}
+		set, err := opt.parse(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", opt.env(), err))
+			continue
+		}
+		o.env[opt.name] = set
+	}
+	return errors.Join(errs...)
+}
+
+// Apply overrides the settings of a configuration, usually one loaded from the config file.
+func (o *ConfigOverrides) Apply(c *Config) {
+	for _, set := range []map[string]func(*Config){o.env, o.flags} {
+		for _, opt := range configOptions {
+			if apply, ok := set[opt.name]; ok {
+				apply(c)
+			}
+		}
+	}
+}
+
+func NewConfigOverrides() *ConfigOverrides {
+	return &ConfigOverrides{
+		env:   make(map[string]func(*Config)),
+		flags: make(map[string]func(*Config)),
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigOverrides(t *testing.T) {
+	// parse registers the overrides on a new flag set, then reads args and env into them
+	parse := func(t *testing.T, args []string, env map[string]string) (*ConfigOverrides, error) {
+		o := NewConfigOverrides()
+		fs := flag.NewFlagSet("snake", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		o.RegisterFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		return o, o.ReadEnv(func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		})
+	}
+
+	t.Run("leave the config alone when nothing is given", func(t *testing.T) {
+		o, err := parse(t, nil, nil)
+		require.NoError(t, err)
+
+		cfg := expectedConfig
+		o.Apply(&cfg)
+
+		require.Equal(t, expectedConfig, cfg)
+	})
+
+	t.Run("flags override the config", func(t *testing.T) {
+		o, err := parse(t, []string{"-lives", "7", "-apples", "2", "-walls", "wrap", "-seed", "42"}, nil)
+		require.NoError(t, err)
+
+		cfg := expectedConfig
+		o.Apply(&cfg)
+
+		require.Equal(t, uint(7), cfg.NumberOfLives())
+		require.Equal(t, 2, cfg.MaxNumberOfApples())
+		require.Equal(t, WrapWalls, cfg.WallMode())
+		require.Equal(t, int64(42), cfg.Seed())
+		require.Equal(t, expectedConfig.SnakeStartingLength(), cfg.SnakeStartingLength())
+	})
+
+	t.Run("environment variables override the config", func(t *testing.T) {
+		o, err := parse(t, nil, map[string]string{"SNAKE_LENGTH": "4", "SNAKE_PLAYERS": "2"})
+		require.NoError(t, err)
+
+		cfg := expectedConfig
+		o.Apply(&cfg)
+
+		require.Equal(t, 4, cfg.SnakeStartingLength())
+		require.Equal(t, 2, cfg.Players())
+	})
+
+	t.Run("flags override environment variables", func(t *testing.T) {
+		o, err := parse(t, []string{"-lives", "2"}, map[string]string{"SNAKE_LIVES": "5", "SNAKE_BOTS": "1"})
+		require.NoError(t, err)
+
+		var cfg Config
+		o.Apply(&cfg)
+
+		require.Equal(t, uint(2), cfg.NumberOfLives())
+		require.Equal(t, 1, cfg.Bots())
+	})
+
+	t.Run("a board too small for the file's snake can be widened", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, json.Unmarshal([]byte(`{"snakeStartingLength": 30}`), &cfg))
+		o, err := parse(t, nil, map[string]string{"SNAKE_WIDTH": "100"})
+		require.NoError(t, err)
+
+		o.Apply(&cfg)
+
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("empty environment variables are ignored", func(t *testing.T) {
+		o, err := parse(t, nil, map[string]string{"SNAKE_LIVES": ""})
+		require.NoError(t, err)
+
+		cfg := expectedConfig
+		o.Apply(&cfg)
+
+		require.Equal(t, expectedConfig, cfg)
+	})
+
+	t.Run("rejects bad flags", func(t *testing.T) {
+		for _, args := range [][]string{{"-lives", "0"}, {"-apples", "many"}, {"-walls", "bouncy"}, {"-bots", "-1"}} {
+			_, err := parse(t, args, nil)
+			require.Error(t, err, args)
+		}
+	})
+
+	t.Run("reports every bad environment variable", func(t *testing.T) {
+		_, err := parse(t, nil, map[string]string{"SNAKE_LIVES": "-3", "SNAKE_SEED": "x", "SNAKE_WIDTH": "50"})
+
+		require.ErrorContains(t, err, "SNAKE_LIVES: ")
+		require.ErrorContains(t, err, "SNAKE_SEED: ")
+		require.NotContains(t, err.Error(), "SNAKE_WIDTH")
+	})
+}
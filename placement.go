@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"snake/ui"
+)
+
+// Placement controls which of the free cells a new apple can be placed on.
+type Placement int
+
+const (
+	// UniformPlacement places apples on any free cell with equal chance.
+	UniformPlacement Placement = iota
+	// FarPlacement places apples in the half of the free cells furthest from the first
+	// player's head.
+	FarPlacement
+	// WallsPlacement places apples next to the walls or an obstacle.
+	WallsPlacement
+	// ReachablePlacement only places apples where the first player's snake can get to them.
+	ReachablePlacement
+)
+
+var placements = []Placement{UniformPlacement, FarPlacement, WallsPlacement, ReachablePlacement}
+
+func (p Placement) String() string {
+	switch p {
+	case UniformPlacement:
+		return "uniform"
+	case FarPlacement:
+		return "far-from-head"
+	case WallsPlacement:
+		return "near-walls"
+	case ReachablePlacement:
+		return "reachable"
+	default:
+		return fmt.Sprintf("unrecognized placement: %d", int(p))
+	}
+}
+
+func (p Placement) MarshalText() ([]byte, error) {
+	switch p {
+	case UniformPlacement, FarPlacement, WallsPlacement, ReachablePlacement:
+		return []byte(p.String()), nil
+	default:
+		return nil, fmt.Errorf("unrecognized placement: %d", int(p))
+	}
+}
+
+func (p *Placement) UnmarshalText(text []byte) error {
+	for _, placement := range placements {
+		if placement.String() == string(text) {
+			*p = placement
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized placement: %q", text)
+}
+
+// candidates narrows the free cells down to those the strategy allows. When none of them
+// fit, every free cell is allowed, so an apple is placed whenever there's room for one.
+func (p Placement) candidates(b *gameBoard, free []ui.Position) []ui.Position {
+	var ret []ui.Position
+	switch p {
+	case FarPlacement:
+		ret = farFromHead(b, free)
+	case WallsPlacement:
+		ret = nearWalls(b, free)
+	case ReachablePlacement:
+		ret = reachable(b, free)
+	default:
+		return free
+	}
+	if len(ret) == 0 {
+		return free
+	}
+	return ret
+}
+
+// farFromHead returns the free cells at least half as far from the first player's head as
+// the furthest one.
+func farFromHead(b *gameBoard, free []ui.Position) []ui.Position {
+	if b.snake == nil {
+		return nil
+	}
+	g, head := b.grid(), b.snake.head()
+	furthest := 0
+	for _, c := range free {
+		furthest = max(furthest, g.Distance(head, c))
+	}
+	var ret []ui.Position
+	for _, c := range free {
+		if g.Distance(head, c)*2 >= furthest {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// nearWalls returns the free cells next to the edge of the board or an obstacle.
+func nearWalls(b *gameBoard, free []ui.Position) []ui.Position {
+	var ret []ui.Position
+	for _, c := range free {
+		for _, d := range directions {
+			dx, dy := d.offset()
+			if !b.IsInside(ui.Position{X: c.X + dx, Y: c.Y + dy}) {
+				ret = append(ret, c)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// reachable returns the free cells the first player's snake can reach without going through
+// a wall or a snake.
+func reachable(b *gameBoard, free []ui.Position) []ui.Position {
+	if b.snake == nil {
+		return nil
+	}
+	reach := make(map[ui.Position]struct{})
+	for _, c := range b.grid().Reachable(b.snake.head()) {
+		reach[c] = struct{}{}
+	}
+	var ret []ui.Position
+	for _, c := range free {
+		if _, ok := reach[c]; ok {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
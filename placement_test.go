@@ -0,0 +1,107 @@
+package main
+
+import (
+	"snake/ui"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Placement(t *testing.T) {
+	for _, p := range placements {
+		t.Run("round trips "+p.String()+" through text", func(t *testing.T) {
+			text, err := p.MarshalText()
+			require.NoError(t, err)
+
+			var act Placement
+			require.NoError(t, act.UnmarshalText(text))
+			require.Equal(t, p, act)
+		})
+	}
+
+	t.Run("rejects unknown placements", func(t *testing.T) {
+		var p Placement
+		require.Error(t, p.UnmarshalText([]byte("anywhere")))
+	})
+}
+
+func Test_PlacementCandidates(t *testing.T) {
+	// newBoard returns an empty board with the snake's head in the top left corner.
+	newBoard := func() *gameBoard {
+		b := newGameBoard(ui.Position{X: 0, Y: 0}, minWidth, minHeight, normalApplesConfig())
+		b.apples = nil
+		b.snake.Body = []ui.Position{{X: b.Left() + 1, Y: b.Top() + 1}}
+		return b
+	}
+
+	t.Run("uniform allows every free cell", func(t *testing.T) {
+		b := newBoard()
+		free := b.freeCells()
+
+		require.Equal(t, free, UniformPlacement.candidates(b, free))
+	})
+
+	t.Run("far from head leaves out the cells near the head", func(t *testing.T) {
+		b := newBoard()
+		g, head := b.grid(), b.snake.head()
+		free := b.freeCells()
+
+		cells := FarPlacement.candidates(b, free)
+
+		require.NotEmpty(t, cells)
+		require.Less(t, len(cells), len(free))
+		furthest := g.Distance(head, ui.Position{X: b.Right() - 1, Y: b.Bottom() - 1})
+		for _, c := range cells {
+			require.GreaterOrEqual(t, g.Distance(head, c)*2, furthest)
+		}
+	})
+
+	t.Run("near walls only allows cells beside a wall or obstacle", func(t *testing.T) {
+		b := newBoard()
+		obstacle := b.Center()
+		b.obstacles = map[ui.Position]struct{}{obstacle: {}}
+
+		cells := WallsPlacement.candidates(b, b.freeCells())
+
+		require.Contains(t, cells, ui.Position{X: b.Right() - 1, Y: b.Center().Y})
+		require.Contains(t, cells, ui.Position{X: obstacle.X + 1, Y: obstacle.Y})
+		require.NotContains(t, cells, ui.Position{X: obstacle.X + 2, Y: obstacle.Y})
+	})
+
+	t.Run("reachable leaves out cells the snake is walled off from", func(t *testing.T) {
+		b := newBoard()
+		// wall off the right half of the board
+		b.obstacles = make(map[ui.Position]struct{})
+		for y := b.Top() + 1; y < b.Bottom(); y++ {
+			b.obstacles[ui.Position{X: b.Center().X, Y: y}] = struct{}{}
+		}
+
+		cells := ReachablePlacement.candidates(b, b.freeCells())
+
+		require.NotEmpty(t, cells)
+		for _, c := range cells {
+			require.Less(t, c.X, b.Center().X)
+		}
+	})
+
+	t.Run("falls back to every free cell when none fit", func(t *testing.T) {
+		b := newBoard()
+		free := []ui.Position{{X: b.Right() - 1, Y: b.Bottom() - 1}}
+		// box the head into its corner so nothing can be reached
+		corner := b.snake.head()
+		b.snake.Body = []ui.Position{{X: corner.X + 1, Y: corner.Y}, {X: corner.X, Y: corner.Y + 1}, corner}
+
+		require.Equal(t, free, ReachablePlacement.candidates(b, free))
+	})
+
+	t.Run("apples are only placed on allowed cells", func(t *testing.T) {
+		b := newBoard()
+		b.cfg.applePlacement = FarPlacement
+		cells := FarPlacement.candidates(b, b.freeCells())
+
+		for range 50 {
+			a := newApple(b)
+			require.Contains(t, cells, a.Pos)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"snake/ui"
+	"time"
+)
+
+const (
+	// powerUpMinSpawnDelay and powerUpMaxSpawnDelay bound how long it takes for a power-up to
+	// appear after the last one was collected or disappeared.
+	powerUpMinSpawnDelay = 10 * time.Second
+	powerUpMaxSpawnDelay = 20 * time.Second
+	// powerUpLifetime is how long a power-up stays on the board.
+	powerUpLifetime = 8 * time.Second
+)
+
+// powerUp is a pickup that grants the snake a temporary effect. There's at most one on the
+// board at a time, appearing at random and disappearing again if it's not collected.
+type powerUp struct {
+	ui.PowerUpRenderer
+	// timer counts down to the power-up appearing while it's hidden, and to it disappearing
+	// while it's visible.
+	timer time.Duration
+}
+
+func (p *powerUp) Update(board *gameBoard, delta time.Duration) {
+	if p.timer -= delta; p.timer > 0 {
+		return
+	}
+	if p.Visible {
+		p.reset(board)
+		return
+	}
+	free := board.freeCells()
+	if len(free) == 0 {
+		p.reset(board)
+		return
+	}
+	p.Visible = true
+	p.Kind = ui.PowerUpKinds[board.rng.Intn(len(ui.PowerUpKinds))]
+	p.Pos = free[board.rng.Intn(len(free))]
+	p.timer = powerUpLifetime
+}
+
+// collect takes the power-up off the board, returning the effect it grants.
+func (p *powerUp) collect(board *gameBoard) ui.EffectKind {
+	p.reset(board)
+	return p.Kind
+}
+
+// reset hides the power-up until it's time for the next one to appear.
+func (p *powerUp) reset(board *gameBoard) {
+	p.Visible = false
+	p.timer = powerUpMinSpawnDelay + time.Duration(board.rng.Int63n(int64(powerUpMaxSpawnDelay-powerUpMinSpawnDelay)))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math/rand"
+	"snake/ui"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PowerUp(t *testing.T) {
+	board := &gameBoard{
+		GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, 20, 20),
+		rng:               rand.New(rand.NewSource(1)),
+		cfg:               &Config{},
+	}
+
+	t.Run("appears after the spawn delay", func(t *testing.T) {
+		var p powerUp
+		p.reset(board)
+		require.False(t, p.Visible)
+		require.GreaterOrEqual(t, p.timer, powerUpMinSpawnDelay)
+		require.Less(t, p.timer, powerUpMaxSpawnDelay)
+
+		p.Update(board, p.timer)
+
+		require.True(t, p.Visible)
+		require.True(t, board.IsInside(p.Pos))
+		require.Contains(t, ui.PowerUpKinds, p.Kind)
+	})
+
+	t.Run("disappears when not collected", func(t *testing.T) {
+		var p powerUp
+		p.Update(board, 0)
+		require.True(t, p.Visible)
+
+		p.Update(board, powerUpLifetime)
+
+		require.False(t, p.Visible)
+		require.GreaterOrEqual(t, p.timer, powerUpMinSpawnDelay)
+	})
+
+	t.Run("collecting hides it and returns its effect", func(t *testing.T) {
+		var p powerUp
+		p.Update(board, 0)
+		kind := p.Kind
+
+		require.Equal(t, kind, p.collect(board))
+		require.False(t, p.Visible)
+	})
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ReplayEvent is an Event along with the tick it was received on. Ticks are counted from the
+// start of the game and each one lasts TickDuration. ResizeScreen events also hold the new
+// size of the screen.
+type ReplayEvent struct {
+	Tick   uint64 `json:"tick"`
+	Event  Event  `json:"event"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// Replay holds everything needed to reproduce a game: the seed, the configuration, the size of
+// the board, the level if one was played, and every event the player produced.
+type Replay struct {
+	TicksPerSecond int           `json:"ticksPerSecond"`
+	Seed           int64         `json:"seed"`
+	Width          int           `json:"width"`
+	Height         int           `json:"height"`
+	Config         *Config       `json:"config"`
+	Level          *Level        `json:"level,omitempty"`
+	Events         []ReplayEvent `json:"events"`
+}
+
+func (r *Replay) record(tick uint64, event Event) {
+	if event == Unknown {
+		return
+	}
+	r.Events = append(r.Events, ReplayEvent{Tick: tick, Event: event})
+}
+
+func (r *Replay) recordResize(tick uint64, width int, height int) {
+	r.Events = append(r.Events, ReplayEvent{Tick: tick, Event: ResizeScreen, Width: width, Height: height})
+}
+
+// Save writes the replay to a file, replacing it if it already exists.
+func (r *Replay) Save(filename string) error {
+	data, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+func newReplay(g *game) *Replay {
+	cfg := *g.cfg
+	return &Replay{
+		TicksPerSecond: TicksPerSecond,
+		Seed:           g.seed,
+		Width:          g.gameBoard.Width(),
+		Height:         g.gameBoard.Height(),
+		Config:         &cfg,
+		Level:          g.gameBoard.level,
+	}
+}
+
+// LoadReplay loads a replay from a file.
+func LoadReplay(filename string) (*Replay, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open: %w", err)
+	}
+	defer file.Close()
+
+	var ret Replay
+	if err = json.NewDecoder(file).Decode(&ret); err != nil {
+		return nil, err
+	}
+	if ret.TicksPerSecond != TicksPerSecond {
+		return nil, fmt.Errorf("replay recorded at %d ticks per second, expected %d", ret.TicksPerSecond, TicksPerSecond)
+	}
+	if ret.Config == nil {
+		ret.Config = &Config{}
+	}
+	if _, err = newReplayGame(&ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// replayGame plays back a recorded game. Events from the replay are fed to the game and the
+// snake on the tick they were recorded, while the player can only exit.
+type replayGame struct {
+	*game
+	events []ReplayEvent
+	next   int
+}
+
+func (r *replayGame) Handle(ev tcell.Event) {
+	if _, ok := ev.(*tcell.EventResize); ok {
+		// the screen the replay is watched on lays out the board, but mustn't pause the game
+		// like the recorded resizes do, or the replay would play out differently
+		r.SetResizeEventCallback(nil)
+		r.Manager.Handle(ev)
+		r.SetResizeEventCallback(r.resizeHandler)
+		return
+	}
+	if r.gameBoard.keys.Get(ev) == ExitGame {
+		r.finished = true
+	}
+}
+
+func (r *replayGame) Update(delta time.Duration) {
+	for r.next < len(r.events) && r.events[r.next].Tick <= r.ticks {
+		switch e := r.events[r.next]; e.Event {
+		case ResizeScreen:
+			r.Manager.Handle(tcell.NewEventResize(e.Width, e.Height))
+		default:
+			r.handleEvent(e.Event)
+			r.gameBoard.notify(e.Event)
+		}
+		r.next += 1
+	}
+	if r.finished {
+		return
+	}
+	r.game.Update(delta)
+	if _, ok := r.currentState.(*menuState); ok {
+		r.finished = true
+	}
+}
+
+// newReplayGame sets up a game to play back a replay, reporting why it can't be when the
+// replay's level can't be laid out.
+func newReplayGame(r *Replay) (*replayGame, error) {
+	cfg := *r.Config
+	cfg.SetSeed(r.Seed)
+	g := newSnakeGame(&cfg, r.Width, r.Height)
+	if r.Level != nil {
+		if err := g.setLevel(r.Level); err != nil {
+			return nil, err
+		}
+	}
+	g.handleEvent(StartGame)
+	return &replayGame{
+		game:   g,
+		events: r.Events,
+	}, nil
+}
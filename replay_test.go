@@ -0,0 +1,220 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Replay(t *testing.T) {
+	t.Run("records events with the tick they were received on", func(t *testing.T) {
+		g := recordGame(&Config{}, 41)
+
+		require.NotNil(t, g.recording)
+		assert.Equal(t, []ReplayEvent{
+			{Tick: 0, Event: MoveUp},
+			{Tick: 20, Event: MoveLeft},
+			{Tick: 40, Event: PauseGame},
+		}, g.recording.Events)
+		assert.Equal(t, g.seed, g.recording.Seed)
+	})
+
+	t.Run("no recording until a game is started", func(t *testing.T) {
+		g := newSnakeGame(&Config{}, 20, 20)
+
+		g.Handle(keyPress(tcell.KeyUp, 0))
+
+		assert.Nil(t, g.recording)
+	})
+
+	t.Run("stops recording once the game is over", func(t *testing.T) {
+		for _, after := range []state{&gameOverState{}, &boardClearedState{delay: time.Second}, &menuState{}} {
+			g := recordGame(&Config{}, 41)
+			events := slices.Clone(g.recording.Events)
+			g.currentState = after
+
+			g.Handle(keyPress(tcell.KeyDown, 0))
+			g.Handle(tcell.NewEventResize(30, 30))
+
+			assert.Equal(t, events, g.recording.Events, "%T", after)
+		}
+	})
+
+	t.Run("replaying a recording reproduces the game", func(t *testing.T) {
+		const ticks = 60 * 30
+		g := recordGame(&Config{}, ticks)
+
+		r := startReplay(t, g.recording)
+		for range ticks {
+			r.Update(TickDuration)
+		}
+
+		assert.Equal(t, g.gameBoard.snake.Body, r.gameBoard.snake.Body)
+		assert.Equal(t, g.gameBoard.apples, r.gameBoard.apples)
+		assert.Equal(t, g.score, r.score)
+		assert.Equal(t, g.remainingLives, r.remainingLives)
+	})
+
+	t.Run("replays both players of a two-player game", func(t *testing.T) {
+		const ticks = 60 * 10
+		g := recordGame(&Config{players: 2}, ticks)
+
+		r := startReplay(t, g.recording)
+		for range ticks {
+			r.Update(TickDuration)
+		}
+
+		assert.Equal(t, g.gameBoard.snake.Body, r.gameBoard.snake.Body)
+		assert.Equal(t, g.gameBoard.playerTwo().Body, r.gameBoard.playerTwo().Body)
+		assert.Equal(t, g.playerTwoStats, r.playerTwoStats)
+	})
+
+	t.Run("records and replays screen resizes", func(t *testing.T) {
+		g := recordGame(&Config{}, 30)
+		g.Handle(tcell.NewEventResize(25, 25))
+		for range 60 {
+			g.Update(TickDuration)
+		}
+
+		require.Contains(t, g.recording.Events, ReplayEvent{Tick: 30, Event: ResizeScreen, Width: 25, Height: 25})
+
+		r := startReplay(t, g.recording)
+		for range 90 {
+			r.Update(TickDuration)
+		}
+		assert.Equal(t, 25, r.gameBoard.Width())
+		assert.Equal(t, g.gameBoard.snake.Body, r.gameBoard.snake.Body)
+		assert.IsType(t, g.currentState, r.currentState)
+	})
+
+	t.Run("keys pressed during playback are ignored, except exit", func(t *testing.T) {
+		g := recordGame(&Config{}, 1)
+		r := startReplay(t, g.recording)
+		r.Update(TickDuration)
+		dir := r.gameBoard.snake.dir
+
+		r.Handle(keyPress(tcell.KeyLeft, 0))
+		r.Update(TickDuration)
+		require.Equal(t, dir, r.gameBoard.snake.dir)
+		require.False(t, r.Finished())
+
+		r.Handle(keyPress(tcell.KeyCtrlC, 0))
+		require.True(t, r.Finished())
+	})
+
+	t.Run("survives a round trip through a file", func(t *testing.T) {
+		cfg := &Config{maxNumberOfApples: 4, numberOfLives: 2}
+		g := recordGame(cfg, 100)
+		filename := filepath.Join(t.TempDir(), "replay.json")
+
+		require.NoError(t, g.recording.Save(filename))
+		act, err := LoadReplay(filename)
+
+		require.NoError(t, err)
+		require.Equal(t, g.recording, act)
+	})
+
+	t.Run("records and replays the level", func(t *testing.T) {
+		g := newSnakeGame(&Config{}, 30, 30)
+		require.NoError(t, g.setLevel(loadTestLevel(t, "direction: up")))
+		g.Handle(keyPress(tcell.KeyEnter, 0))
+		for range 200 {
+			g.Update(TickDuration)
+		}
+		filename := filepath.Join(t.TempDir(), "replay.json")
+		require.NoError(t, g.recording.Save(filename))
+
+		act, err := LoadReplay(filename)
+		require.NoError(t, err)
+		require.Equal(t, g.recording.Level, act.Level)
+
+		r := startReplay(t, act)
+		for range 200 {
+			r.Update(TickDuration)
+		}
+		assert.Equal(t, g.gameBoard.snake.Body, r.gameBoard.snake.Body)
+		assert.Equal(t, g.remainingLives, r.remainingLives)
+	})
+
+	t.Run("rejects replays recorded at a different tick rate", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "replay.json")
+		require.NoError(t, os.WriteFile(filename, []byte(`{"ticksPerSecond": 30}`), 0o644))
+
+		_, err := LoadReplay(filename)
+
+		require.Error(t, err)
+	})
+
+	t.Run("rejects replays whose level can't be laid out", func(t *testing.T) {
+		r := &Replay{
+			TicksPerSecond: TicksPerSecond,
+			Width:          30,
+			Height:         30,
+			Config:         &Config{snakeStartingLength: 10},
+			Level:          loadTestLevel(t),
+		}
+		filename := filepath.Join(t.TempDir(), "replay.json")
+		require.NoError(t, r.Save(filename))
+
+		_, err := newReplayGame(r)
+		require.Error(t, err)
+		_, err = LoadReplay(filename)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects two-player replays with a level", func(t *testing.T) {
+		r := &Replay{
+			TicksPerSecond: TicksPerSecond,
+			Width:          30,
+			Height:         30,
+			Config:         &Config{players: 2},
+			Level:          loadTestLevel(t),
+		}
+		filename := filepath.Join(t.TempDir(), "replay.json")
+		require.NoError(t, r.Save(filename))
+
+		_, err := LoadReplay(filename)
+
+		require.ErrorIs(t, err, ErrSinglePlayerOnly)
+	})
+}
+
+func keyPress(key tcell.Key, ch rune) *tcell.EventKey {
+	return tcell.NewEventKey(key, ch, tcell.ModNone)
+}
+
+// recordGame starts a game and presses a key every few ticks, returning the game once the
+// number of ticks has passed.
+func recordGame(cfg *Config, ticks int) *game {
+	g := newSnakeGame(cfg, 30, 30)
+	g.Handle(keyPress(tcell.KeyEnter, 0))
+	keys := []*tcell.EventKey{
+		keyPress(tcell.KeyUp, 0),
+		keyPress(tcell.KeyLeft, 0),
+		keyPress(tcell.KeyRune, ' '),
+		keyPress(tcell.KeyRune, ' '),
+		keyPress(tcell.KeyDown, 0),
+		keyPress(tcell.KeyRight, 0),
+	}
+	for i := range ticks {
+		if i%20 == 0 {
+			g.Handle(keys[(i/20)%len(keys)])
+		}
+		g.Update(TickDuration)
+	}
+	return g
+}
+
+// startReplay sets up a game to play back a replay, failing the test if it can't be.
+func startReplay(t *testing.T, r *Replay) *replayGame {
+	t.Helper()
+	ret, err := newReplayGame(r)
+	require.NoError(t, err)
+	return ret
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"snake/ui"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	replayViewName      = "Replay"
+	replayStatusFormat  = "%s Tick %d/%d %gx"
+	replayPlayingText   = "Playing"
+	replayPausedText    = "Paused"
+	defaultReplaySpeed  = 1
+	replayJumpTicks     = 5 * TicksPerSecond
+	replayJumpDivisions = 10
+	// replayTailTicks limits how long a replay keeps playing after its last event, in case it
+	// was saved before the game ended.
+	replayTailTicks = 60 * TicksPerSecond
+)
+
+// replaySpeeds are the playback speeds the viewer steps through, as multiples of real time.
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4, 8}
+
+type replayControl int
+
+const (
+	noReplayControl replayControl = iota
+	togglePlayback
+	stepForward
+	stepBack
+	speedUp
+	slowDown
+	jumpToStart
+	jumpToEnd
+	jumpForward
+	jumpBack
+	nextMarker
+	previousMarker
+	exitReplay
+)
+
+func replayControlFromKey(ev *tcell.EventKey) replayControl {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case ' ':
+			return togglePlayback
+		case '+', '=':
+			return speedUp
+		case '-', '_':
+			return slowDown
+		case ']':
+			return nextMarker
+		case '[':
+			return previousMarker
+		case 'q', 'Q':
+			return exitReplay
+		}
+	case tcell.KeyRight:
+		return stepForward
+	case tcell.KeyLeft:
+		return stepBack
+	case tcell.KeyHome:
+		return jumpToStart
+	case tcell.KeyEnd:
+		return jumpToEnd
+	case tcell.KeyPgDn:
+		return jumpForward
+	case tcell.KeyPgUp:
+		return jumpBack
+	case tcell.KeyCtrlC, tcell.KeyEscape:
+		return exitReplay
+	}
+	return noReplayControl
+}
+
+// replayViewer plays back a Replay with controls for pausing, stepping, seeking and changing the
+// playback speed. Since replays are deterministic, seeking backwards replays the game from the
+// start up to the requested tick.
+type replayViewer struct {
+	replay   *Replay
+	sim      *replayGame
+	view     *ui.ReplayView
+	total    uint64
+	markers  []ui.TimelineMarker
+	paused   bool
+	speed    int
+	pending  float64
+	finished bool
+	// screen is the last resize of the screen the replay is watched on, if there's been one.
+	screen *tcell.EventResize
+}
+
+func (v *replayViewer) Handle(ev tcell.Event) {
+	if resize, ok := ev.(*tcell.EventResize); ok {
+		v.screen = resize
+		v.sim.Handle(resize)
+		return
+	}
+	key, ok := ev.(*tcell.EventKey)
+	if !ok {
+		return
+	}
+	if key.Key() == tcell.KeyRune && key.Rune() >= '0' && key.Rune() <= '9' {
+		v.seek(v.total * uint64(key.Rune()-'0') / replayJumpDivisions)
+		return
+	}
+
+	switch replayControlFromKey(key) {
+	case togglePlayback:
+		v.paused = !v.paused
+		if !v.paused && v.tick() >= v.total {
+			v.seek(0)
+		}
+	case stepForward:
+		v.paused = true
+		v.seek(v.tick() + 1)
+	case stepBack:
+		v.paused = true
+		if v.tick() > 0 {
+			v.seek(v.tick() - 1)
+		}
+	case speedUp:
+		v.speed = min(v.speed+1, len(replaySpeeds)-1)
+	case slowDown:
+		v.speed = max(v.speed-1, 0)
+	case jumpToStart:
+		v.seek(0)
+	case jumpToEnd:
+		v.seek(v.total)
+	case jumpForward:
+		v.seek(v.tick() + replayJumpTicks)
+	case jumpBack:
+		v.seek(v.tick() - min(v.tick(), replayJumpTicks))
+	case nextMarker:
+		if i := slices.IndexFunc(v.markers, func(m ui.TimelineMarker) bool { return m.Tick > v.tick() }); i >= 0 {
+			v.seek(v.markers[i].Tick)
+		}
+	case previousMarker:
+		for i := len(v.markers) - 1; i >= 0; i-- {
+			if v.markers[i].Tick < v.tick() {
+				v.seek(v.markers[i].Tick)
+				break
+			}
+		}
+	case exitReplay:
+		v.finished = true
+	}
+	v.refresh()
+}
+
+// Update advances the replay by the current speed. RunGame calls it once per tick, so at 1x
+// one recorded tick is played for every real one. Playback waits while the screen is too small.
+func (v *replayViewer) Update(time.Duration) {
+	if !v.paused && !v.sim.TooSmall() {
+		v.pending += replaySpeeds[v.speed]
+		for ; v.pending >= 1; v.pending -= 1 {
+			if !v.step() {
+				v.paused = true
+				v.pending = 0
+				break
+			}
+		}
+	}
+	v.refresh()
+}
+
+func (v *replayViewer) Draw(scrn tcell.Screen) {
+	v.sim.Draw(scrn)
+}
+
+func (v *replayViewer) Finished() bool {
+	return v.finished
+}
+
+func (v *replayViewer) tick() uint64 {
+	return v.sim.ticks
+}
+
+// step plays one tick, reporting whether there was one to play. Nothing is played while the
+// screen is too small.
+func (v *replayViewer) step() bool {
+	if v.tick() >= v.total || v.sim.TooSmall() {
+		return false
+	}
+	v.sim.Update(TickDuration)
+	return true
+}
+
+func (v *replayViewer) seek(tick uint64) {
+	tick = min(tick, v.total)
+	if tick < v.tick() {
+		v.restart()
+	}
+	for v.tick() < tick && v.step() {
+	}
+}
+
+func (v *replayViewer) restart() {
+	// newReplayViewer has already played the replay, so it can be set up again
+	v.sim, _ = newReplayGame(v.replay)
+	v.view = ui.NewReplayView(ui.Position{X: 0, Y: 0}, v.sim.gameBoard)
+	v.sim.AddView(replayViewName, v.view)
+	_ = v.sim.SwitchView(replayViewName)
+	if v.screen != nil {
+		v.sim.Handle(v.screen)
+	}
+	v.refresh()
+}
+
+func (v *replayViewer) refresh() {
+	status := replayPlayingText
+	if v.paused {
+		status = replayPausedText
+	}
+	v.view.Timeline().
+		SetTotal(v.total).
+		SetCurrent(v.tick()).
+		SetMarkers(v.markers).
+		SetStatus(fmt.Sprintf(replayStatusFormat, status, v.tick(), v.total, replaySpeeds[v.speed]))
+}
+
+// scanReplay plays a replay to the end, returning its length in ticks along with where apples
+// were eaten and lives were lost.
+func scanReplay(r *Replay) (uint64, []ui.TimelineMarker, error) {
+	limit := uint64(replayTailTicks)
+	if len(r.Events) > 0 {
+		limit += r.Events[len(r.Events)-1].Tick
+	}
+
+	var markers []ui.TimelineMarker
+	sim, err := newReplayGame(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	for !sim.Finished() && sim.ticks < limit {
+		eaten, lives := sim.applesEaten, sim.remainingLives
+		sim.Update(TickDuration)
+		if sim.applesEaten > eaten {
+			markers = append(markers, ui.TimelineMarker{Tick: sim.ticks, Kind: ui.AppleMarker})
+		}
+		if sim.remainingLives < lives {
+			markers = append(markers, ui.TimelineMarker{Tick: sim.ticks, Kind: ui.LifeLostMarker})
+		}
+	}
+	return sim.ticks, markers, nil
+}
+
+func newReplayViewer(r *Replay) (*replayViewer, error) {
+	total, markers, err := scanReplay(r)
+	if err != nil {
+		return nil, err
+	}
+	ret := replayViewer{
+		replay:  r,
+		total:   total,
+		markers: markers,
+		speed:   slices.Index(replaySpeeds, defaultReplaySpeed),
+	}
+	ret.restart()
+	return &ret, nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"slices"
+	"snake/ui"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReplayViewer(t *testing.T) {
+	// the snake turns back into its own body, losing a life on its third move
+	crashReplay := func() *Replay {
+		return &Replay{
+			TicksPerSecond: TicksPerSecond,
+			Seed:           1,
+			Width:          20,
+			Height:         20,
+			Config:         &Config{snakeStartingLength: 5},
+			Events: []ReplayEvent{
+				{Tick: 0, Event: MoveUp},
+				{Tick: 1, Event: MoveLeft},
+				{Tick: 20, Event: MoveDown},
+				{Tick: 100, Event: ExitGame},
+			},
+		}
+	}
+	press := func(v *replayViewer, key tcell.Key, ch rune) {
+		v.Handle(keyPress(key, ch))
+	}
+
+	t.Run("replay ends when the player exited", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		require.Equal(t, uint64(100), v.total)
+		require.Equal(t, uint64(0), v.tick())
+		require.False(t, v.paused)
+	})
+
+	t.Run("marks lives lost", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		require.Contains(t, v.markers, ui.TimelineMarker{Tick: 33, Kind: ui.LifeLostMarker})
+	})
+
+	t.Run("plays one tick per update at normal speed", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		v.Update(TickDuration)
+		v.Update(TickDuration)
+
+		require.Equal(t, uint64(2), v.tick())
+	})
+
+	t.Run("pausing stops playback", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		press(v, tcell.KeyRune, ' ')
+		v.Update(TickDuration)
+
+		require.True(t, v.paused)
+		require.Equal(t, uint64(0), v.tick())
+	})
+
+	t.Run("pauses when the end is reached", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		for range v.total + 10 {
+			v.Update(TickDuration)
+		}
+
+		require.True(t, v.paused)
+		require.Equal(t, v.total, v.tick())
+		require.False(t, v.Finished())
+	})
+
+	t.Run("steps forward and back one tick", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		press(v, tcell.KeyRight, 0)
+		press(v, tcell.KeyRight, 0)
+		press(v, tcell.KeyRight, 0)
+		require.Equal(t, uint64(3), v.tick())
+		require.True(t, v.paused)
+
+		press(v, tcell.KeyLeft, 0)
+		require.Equal(t, uint64(2), v.tick())
+	})
+
+	t.Run("seeking back reproduces the game", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+		v.seek(40)
+		exp := slices.Clone(v.sim.gameBoard.snake.Body)
+		lives := v.sim.remainingLives
+
+		v.seek(90)
+		v.seek(40)
+
+		require.Equal(t, exp, v.sim.gameBoard.snake.Body)
+		require.Equal(t, lives, v.sim.remainingLives)
+	})
+
+	t.Run("jumps between markers", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		press(v, tcell.KeyRune, ']')
+		require.Equal(t, uint64(33), v.tick())
+
+		press(v, tcell.KeyEnd, 0)
+		press(v, tcell.KeyRune, '[')
+		require.Equal(t, uint64(33), v.tick())
+	})
+
+	t.Run("number keys jump to a fraction of the replay", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		press(v, tcell.KeyRune, '5')
+
+		require.Equal(t, uint64(50), v.tick())
+	})
+
+	t.Run("speed", func(t *testing.T) {
+		t.Run("plays in real time by default", func(t *testing.T) {
+			v := startReplayViewer(t, crashReplay())
+
+			assert.Equal(t, 1.0, replaySpeeds[v.speed])
+		})
+
+		t.Run("faster plays several ticks per update", func(t *testing.T) {
+			v := startReplayViewer(t, crashReplay())
+
+			press(v, tcell.KeyRune, '+')
+			v.Update(TickDuration)
+
+			require.Equal(t, uint64(2), v.tick())
+		})
+
+		t.Run("slower plays a tick every few updates", func(t *testing.T) {
+			v := startReplayViewer(t, crashReplay())
+
+			press(v, tcell.KeyRune, '-')
+			press(v, tcell.KeyRune, '-')
+			for range 4 {
+				v.Update(TickDuration)
+			}
+
+			require.Equal(t, uint64(1), v.tick())
+		})
+
+		t.Run("is limited to the supported range", func(t *testing.T) {
+			v := startReplayViewer(t, crashReplay())
+
+			for range 10 {
+				press(v, tcell.KeyRune, '+')
+			}
+			assert.Equal(t, 8.0, replaySpeeds[v.speed])
+
+			for range 10 {
+				press(v, tcell.KeyRune, '-')
+			}
+			assert.Equal(t, 0.25, replaySpeeds[v.speed])
+		})
+	})
+
+	t.Run("exits on ctrl-c", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		press(v, tcell.KeyCtrlC, 0)
+
+		require.True(t, v.Finished())
+	})
+
+	t.Run("shows the replay view", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		require.Equal(t, replayViewName, v.sim.ActiveViewName())
+	})
+
+	t.Run("waits while the screen is too small", func(t *testing.T) {
+		v := startReplayViewer(t, crashReplay())
+
+		v.Handle(tcell.NewEventResize(10, 10))
+		v.Update(TickDuration)
+		press(v, tcell.KeyPgDn, 0)
+
+		require.Equal(t, ui.TooSmallViewName, v.sim.ActiveViewName())
+		require.Equal(t, uint64(0), v.tick())
+		require.False(t, v.paused)
+
+		v.Handle(tcell.NewEventResize(20, 20))
+		v.Update(TickDuration)
+
+		require.Equal(t, replayViewName, v.sim.ActiveViewName())
+		require.Equal(t, uint64(1), v.tick())
+	})
+
+	t.Run("fits the board to the screen, even after seeking back", func(t *testing.T) {
+		v := startReplayViewer(t, &Replay{TicksPerSecond: TicksPerSecond, Seed: 1, Width: 30, Height: 30, Config: &Config{}})
+
+		v.Handle(tcell.NewEventResize(25, 25))
+		require.Equal(t, 25, v.sim.gameBoard.Width())
+
+		press(v, tcell.KeyRight, 0)
+		press(v, tcell.KeyHome, 0)
+
+		require.Equal(t, 25, v.sim.gameBoard.Width())
+		require.IsType(t, new(playingState), v.sim.currentState, "resizing doesn't pause the replay")
+	})
+}
+
+// startReplayViewer opens a replay in the viewer, failing the test if it can't be played.
+func startReplayViewer(t *testing.T, r *Replay) *replayViewer {
+	t.Helper()
+	ret, err := newReplayViewer(r)
+	require.NoError(t, err)
+	return ret
+}
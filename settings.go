@@ -0,0 +1,361 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"snake/ui"
+	"strconv"
+)
+
+const (
+	settingsViewName = "Settings"
+	settingsTitle    = "Settings"
+	// SettingsNotSavedText is shown when the settings can't be played or saved, with the reason.
+	SettingsNotSavedText = "Settings not saved: %v"
+	randomSeedText       = "random"
+	seedEntryViewName    = "SeedEntry"
+	seedEntryTitle       = "Seed"
+	seedEntryPrompt      = "Type a seed, or nothing for a random game:"
+	// maxSeedLength fits the longest seed, with its minus sign.
+	maxSeedLength = len("-9223372036854775808")
+)
+
+// The ranges offered on the settings screen.
+const (
+	maxSettingsLives     = 9
+	maxSettingsApples    = 50
+	maxSettingsBoardSize = 200
+	maxAppleWeight       = 100
+	appleWeightStep      = 5
+	// maxSettingsLength is the longest snake that fits between the center and the edge of
+	// the smallest board.
+	maxSettingsLength = (minWidth - 2) / 2
+)
+
+// setting is an option on the settings screen, along with the widget that edits it.
+type setting struct {
+	label string
+	value ui.MenuValue
+	// load shows the option of a configuration in the widget, and store writes it back if
+	// the player changed it, so options left alone are saved exactly as they were loaded.
+	load  func(*Config)
+	store func(*Config)
+	// edit, if set, is run in place of stepping the value when the option is activated.
+	edit func()
+}
+
+// edited notes when the player steps the widget of a setting.
+type edited struct {
+	ui.MenuValue
+	stepped bool
+}
+
+func (e *edited) Step(dir int) {
+	e.MenuValue.Step(dir)
+	e.stepped = true
+}
+
+func stepperSetting(label string, lo, hi, step int, get func(*Config) int, set func(*Config, int)) setting {
+	s := ui.NewStepper(lo, hi, step)
+	e := &edited{MenuValue: s}
+	return setting{
+		label: label,
+		value: e,
+		load: func(c *Config) {
+			s.SetValue(get(c))
+			e.stepped = false
+		},
+		store: func(c *Config) {
+			if e.stepped && s.Value() != get(c) {
+				set(c, s.Value())
+			}
+		},
+	}
+}
+
+func toggleSetting(label string, get func(*Config) bool, set func(*Config, bool)) setting {
+	t := ui.NewToggle()
+	e := &edited{MenuValue: t}
+	return setting{
+		label: label,
+		value: e,
+		load: func(c *Config) {
+			t.SetValue(get(c))
+			e.stepped = false
+		},
+		store: func(c *Config) {
+			if e.stepped && t.Value() != get(c) {
+				set(c, t.Value())
+			}
+		},
+	}
+}
+
+func choiceSetting[T interface {
+	comparable
+	fmt.Stringer
+}](label string, options []T, get func(*Config) T, set func(*Config, T)) setting {
+	names := make([]string, len(options))
+	for i, option := range options {
+		names[i] = option.String()
+	}
+	ch := ui.NewChoice(names...)
+	e := &edited{MenuValue: ch}
+	return setting{
+		label: label,
+		value: e,
+		load: func(c *Config) {
+			ch.Select(max(slices.Index(options, get(c)), 0))
+			e.stepped = false
+		},
+		store: func(c *Config) {
+			if e.stepped && options[ch.Selected()] != get(c) {
+				set(c, options[ch.Selected()])
+			}
+		},
+	}
+}
+
+// seedValue shows the seed on the settings screen, where zero means every game is random. It
+// can't be stepped, since it's typed in instead.
+type seedValue struct {
+	seed  int64
+	typed bool
+}
+
+func (v *seedValue) String() string {
+	if v.seed == 0 {
+		return randomSeedText
+	}
+	return strconv.FormatInt(v.seed, 10)
+}
+
+func (v *seedValue) Step(int) {
+	// do nothing
+}
+
+// seedSetting edits the seed, which is typed in on a screen of its own.
+func seedSetting(g *game) setting {
+	v := new(seedValue)
+	return setting{
+		label: "Seed",
+		value: v,
+		load: func(c *Config) {
+			v.seed = c.Seed()
+			v.typed = false
+		},
+		store: func(c *Config) {
+			if v.typed && v.seed != c.Seed() {
+				c.SetSeed(v.seed)
+			}
+		},
+		edit: func() { g.editSeed(v) },
+	}
+}
+
+// settingGroup is a submenu of the settings screen.
+type settingGroup struct {
+	title    string
+	settings []setting
+}
+
+// settingGroups lists every option of the configuration, grouped into the submenus of the
+// settings screen.
+func settingGroups(g *game) []settingGroup {
+	apples := []setting{
+		choiceSetting("Placement", placements, (*Config).ApplePlacement, (*Config).SetApplePlacement),
+	}
+	for _, kind := range ui.AppleKinds {
+		apples = append(apples, stepperSetting(kind.String(), 0, maxAppleWeight, appleWeightStep,
+			func(c *Config) int { return c.AppleWeight(kind) },
+			func(c *Config, weight int) { c.SetAppleWeight(kind, weight) }))
+	}
+	return []settingGroup{
+		{title: "Game", settings: []setting{
+			stepperSetting("Lives", 1, maxSettingsLives, 1,
+				func(c *Config) int { return int(c.NumberOfLives()) },
+				func(c *Config, lives int) { c.SetNumberOfLives(uint(lives)) }),
+			stepperSetting("Apples", 1, maxSettingsApples, 1, (*Config).MaxNumberOfApples, (*Config).SetMaxNumberOfApples),
+			stepperSetting("Length", 1, maxSettingsLength, 1, (*Config).SnakeStartingLength, (*Config).SetSnakeStartingLength),
+			choiceSetting("Walls", wallModes, (*Config).WallMode, (*Config).SetWallMode),
+			seedSetting(g),
+		}},
+		{title: "Board", settings: []setting{
+			stepperSetting("Width", minWidth, maxSettingsBoardSize, 1, (*Config).BoardWidth, (*Config).SetBoardWidth),
+			stepperSetting("Height", minHeight, maxSettingsBoardSize, 1, (*Config).BoardHeight, (*Config).SetBoardHeight),
+			toggleSetting("Fill", (*Config).FillTerminal, (*Config).SetFillTerminal),
+		}},
+		{title: "Players", settings: []setting{
+			stepperSetting("Players", 1, MaxPlayers, 1, (*Config).Players, (*Config).SetPlayers),
+			stepperSetting("Bots", 0, MaxBots, 1, (*Config).Bots, (*Config).SetBots),
+			choiceSetting("Bot level", difficulties, (*Config).BotDifficulty, (*Config).SetBotDifficulty),
+		}},
+		{title: "Apples", settings: apples},
+	}
+}
+
+// settingsScreen edits the configuration kept in the config file.
+type settingsScreen struct {
+	view     *menuView
+	settings []setting
+	// seedEntry is where the seed is typed, and seed is the value being typed.
+	seedEntry *ui.NameEntryView
+	seed      *seedValue
+	// saved is the configuration as it's kept in the file, without the overrides.
+	saved Config
+	file  string
+	// override applies the command line on top of the saved configuration, so it still
+	// holds after the settings are saved.
+	override func(*Config)
+}
+
+// setSettings lets the player edit the configuration, which is saved to file unless it's
+// empty. override is applied on top of the saved configuration when the game uses it.
+func (g *game) setSettings(saved Config, file string, override func(*Config)) {
+	s := &settingsScreen{
+		view:      newMenuView(g, settingsTitle),
+		seedEntry: ui.NewNameEntryView(maxSeedLength).SetPrompt(seedEntryPrompt),
+		saved:     saved,
+		file:      file,
+		override:  override,
+	}
+	s.seedEntry.SetTitle(seedEntryTitle)
+	s.seedEntry.Field().
+		SetValidator(func(text string) error {
+			_, err := parseSeed(text)
+			return err
+		}).
+		SetOnSubmit(g.submitSeed)
+	s.seedEntry.Resize(g.gameBoard.Width(), g.gameBoard.Height())
+	ul, menuWidth, menuHeight := menuLayout(s.view.GameBoardRenderer)
+	for _, group := range settingGroups(g) {
+		submenu := ui.NewMenu(ul, menuWidth, menuHeight, group.title)
+		for _, st := range group.settings {
+			item := submenu.AddValue(st.label, st.value)
+			if st.edit != nil {
+				item.SetAction(st.edit)
+			}
+			s.settings = append(s.settings, st)
+		}
+		submenu.AddItem("Back", func() { s.view.menu.Back() })
+		s.view.menu.AddSubmenu(group.title, submenu)
+	}
+	s.view.menu.AddItem("Save", g.saveSettings)
+	s.view.menu.AddItem("Cancel", func() { g.currentState = g.menu() })
+	g.settings = s
+	g.AddView(settingsViewName, s.view)
+	g.AddView(seedEntryViewName, s.seedEntry)
+	g.mainMenu.settings.SetDisabled(false)
+}
+
+// showSettings opens the settings screen on the saved configuration.
+func (g *game) showSettings() {
+	s := g.settings
+	for _, st := range s.settings {
+		st.load(&s.saved)
+	}
+	_ = g.SwitchView(settingsViewName)
+	g.currentState = new(settingsState)
+}
+
+// parseSeed reads a typed seed, where nothing stands for a random game.
+func parseSeed(text string) (int64, error) {
+	if text == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(text, 10, 64)
+}
+
+// editSeed asks for a new seed in place of the settings screen.
+func (g *game) editSeed(v *seedValue) {
+	s := g.settings
+	s.seed = v
+	text := ""
+	if v.seed != 0 {
+		text = v.String()
+	}
+	s.seedEntry.Field().SetText(text)
+	_ = g.SwitchView(seedEntryViewName)
+	g.currentState = new(seedEntryState)
+}
+
+// submitSeed takes the seed that was typed back to the settings screen.
+func (g *game) submitSeed(text string) {
+	if _, ok := g.currentState.(*seedEntryState); !ok {
+		return
+	}
+	// the field only submits seeds that parse
+	g.settings.seed.seed, _ = parseSeed(text)
+	g.settings.seed.typed = true
+	g.leaveSeedEntry()
+}
+
+// leaveSeedEntry goes back to the settings screen without loading the saved settings again,
+// so the other changes are kept.
+func (g *game) leaveSeedEntry() {
+	_ = g.SwitchView(settingsViewName)
+	g.currentState = new(settingsState)
+}
+
+// saveSettings writes the edited configuration to the config file and uses it for the games
+// that follow. Settings that can't be played on this screen aren't saved.
+func (g *game) saveSettings() {
+	s := g.settings
+	saved := s.saved
+	for _, st := range s.settings {
+		st.store(&saved)
+	}
+	cfg := saved
+	if s.override != nil {
+		s.override(&cfg)
+	}
+	if err := g.checkConfig(&cfg); err != nil {
+		g.ShowModal(fmt.Sprintf(SettingsNotSavedText, err))
+		return
+	}
+	if s.file != "" {
+		if err := saved.Save(s.file); err != nil {
+			g.ShowModal(fmt.Sprintf(SettingsNotSavedText, err))
+			return
+		}
+	}
+	s.saved = saved
+	g.applyConfig(cfg)
+	g.currentState = g.menu()
+}
+
+// checkConfig reports why a configuration can't be played on the current screen, or on
+// the level being played.
+func (g *game) checkConfig(cfg *Config) error {
+	if errs := cfg.problems(); len(errs) > 0 {
+		// the modal only has room for one line
+		return errs[0]
+	}
+	if level := g.gameBoard.level; level != nil {
+		if cfg.Players() > 1 || cfg.Bots() > 0 {
+			return ErrSinglePlayerOnly
+		}
+		return level.fits(cfg.SnakeStartingLength())
+	}
+	return cfg.ValidateScreenSize(g.screenWidth, g.screenHeight)
+}
+
+// applyConfig rebuilds the board from a configuration that checkConfig has accepted, and
+// lays out the other views for its size.
+func (g *game) applyConfig(cfg Config) {
+	level := g.gameBoard.level
+	*g.cfg = cfg
+	width, height := g.cfg.BoardSize(g.screenWidth, g.screenHeight)
+	g.gameBoard = newGameBoard(ui.Position{X: 0, Y: 0}, width, height, g.cfg)
+	g.AddView(gameBoardViewName, g.gameBoard)
+	if level != nil {
+		_ = g.setLevel(level)
+	}
+	g.mainMenu.Resize(g.screenWidth, g.screenHeight)
+	g.settings.view.Resize(g.screenWidth, g.screenHeight)
+	g.settings.seedEntry.Resize(g.gameBoard.Width(), g.gameBoard.Height())
+	if g.highScores != nil {
+		g.highScores.resize(g.gameBoard.Width(), g.gameBoard.Height())
+	}
+	g.mainMenu.mode.SetText(fmt.Sprintf(modeItemFormat, g.gameBoard.wallMode))
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Settings(t *testing.T) {
+	var g *game
+	var file string
+
+	setup := func(t *testing.T, saved Config, override func(*Config)) {
+		file = filepath.Join(t.TempDir(), ConfigFile)
+		cfg := saved
+		if override != nil {
+			override(&cfg)
+		}
+		g = newSnakeGame(&cfg, 60, 40)
+		g.setSettings(saved, file, override)
+		g.mainMenu.menu.Select(2)
+		g.currentState.handle(g, StartGame)
+	}
+	press := func(events ...Event) {
+		for _, event := range events {
+			g.currentState.handle(g, event)
+		}
+	}
+	// save picks Save, below the four groups of settings
+	save := func() {
+		g.settings.view.menu.Select(4)
+		press(StartGame)
+	}
+	readFile := func(t *testing.T) map[string]any {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		var ret map[string]any
+		require.NoError(t, json.Unmarshal(data, &ret))
+		return ret
+	}
+
+	t.Run("are opened from the main menu", func(t *testing.T) {
+		setup(t, Config{}, nil)
+
+		require.IsType(t, new(settingsState), g.currentState)
+		require.Equal(t, settingsViewName, g.ActiveViewName())
+	})
+
+	t.Run("show the saved values", func(t *testing.T) {
+		setup(t, Config{numberOfLives: 7}, nil)
+
+		require.Equal(t, "Lives", g.settings.settings[0].label)
+		require.Equal(t, "7", g.settings.settings[0].value.String())
+	})
+
+	t.Run("changes are saved and used by the next game", func(t *testing.T) {
+		setup(t, Config{}, nil)
+
+		// step lives up from 3 to 4, then go back out of the game settings
+		press(StartGame, MoveRight, MoveUp, StartGame)
+		save()
+
+		require.IsType(t, new(menuState), g.currentState)
+		require.Equal(t, map[string]any{"numberOfLives": float64(4)}, readFile(t))
+		g.play()
+		require.Equal(t, uint(4), g.remainingLives)
+	})
+
+	t.Run("unchanged values aren't written out", func(t *testing.T) {
+		setup(t, Config{wallMode: WrapWalls}, nil)
+
+		save()
+
+		require.Equal(t, map[string]any{"wallMode": "wrap"}, readFile(t))
+	})
+
+	t.Run("a board that doesn't fit the screen isn't saved", func(t *testing.T) {
+		setup(t, Config{}, nil)
+
+		press(MoveDown, StartGame)
+		for range 60 - DefaultBoardWidth + 1 {
+			press(MoveRight)
+		}
+		press(MoveUp, StartGame)
+		save()
+
+		require.True(t, g.ModalVisible())
+		require.IsType(t, new(settingsState), g.currentState)
+		require.NoFileExists(t, file)
+		require.Zero(t, g.cfg.boardWidth)
+
+		press(MoveDown)
+		require.False(t, g.ModalVisible(), "any key dismisses the reason")
+	})
+
+	t.Run("snakes that don't fit the board aren't saved", func(t *testing.T) {
+		setup(t, Config{players: 2, boardWidth: minWidth, boardHeight: minHeight}, nil)
+
+		press(StartGame, MoveDown, MoveDown)
+		for range maxSettingsLength {
+			press(MoveRight)
+		}
+		press(MoveUp, MoveUp, MoveUp, StartGame)
+		save()
+
+		require.True(t, g.ModalVisible())
+		require.NoFileExists(t, file)
+		require.Zero(t, g.cfg.snakeStartingLength)
+	})
+
+	t.Run("the high scores are laid out for the new board", func(t *testing.T) {
+		setup(t, Config{}, nil)
+		g.setHighScores(&HighScores{}, "")
+
+		press(MoveDown, StartGame)
+		for range 5 {
+			press(MoveLeft)
+		}
+		press(MoveUp, StartGame)
+		save()
+
+		require.Equal(t, DefaultBoardWidth-5, g.gameBoard.Width())
+		require.Equal(t, g.gameBoard.Width(), g.highScores.view.Width())
+		require.Equal(t, g.gameBoard.Width(), g.highScores.entry.Width())
+	})
+
+	t.Run("cancelling leaves the config alone", func(t *testing.T) {
+		setup(t, Config{}, nil)
+
+		press(StartGame, MoveRight, MoveUp, StartGame)
+		press(MoveUp, StartGame)
+
+		require.IsType(t, new(menuState), g.currentState)
+		require.NoFileExists(t, file)
+		require.Equal(t, DefaultNumberOfLives, g.cfg.NumberOfLives())
+	})
+
+	t.Run("left leaves the settings from the top", func(t *testing.T) {
+		setup(t, Config{}, nil)
+
+		press(MoveLeft)
+
+		require.IsType(t, new(menuState), g.currentState)
+		require.Equal(t, mainMenuViewName, g.ActiveViewName())
+	})
+
+	t.Run("overrides aren't saved but still apply", func(t *testing.T) {
+		setup(t, Config{}, func(c *Config) { c.SetSeed(7) })
+
+		press(MoveDown, MoveDown, StartGame, MoveDown, MoveRight, MoveDown, MoveDown, StartGame)
+		save()
+
+		require.Equal(t, map[string]any{"bots": float64(1)}, readFile(t))
+		require.Equal(t, int64(7), g.cfg.Seed())
+		require.Equal(t, 1, g.cfg.Bots())
+		require.Len(t, g.gameBoard.others, 1, "the board is rebuilt for the new settings")
+	})
+
+	t.Run("values outside the ranges of the screen are saved as they were", func(t *testing.T) {
+		setup(t, Config{numberOfLives: 20, maxNumberOfApples: 80, seed: 123456789}, nil)
+
+		require.Equal(t, "20", g.settings.settings[0].value.String(), "lives are shown as they were loaded")
+		save()
+
+		require.Equal(t, map[string]any{"numberOfLives": float64(20), "maxNumberOfApples": float64(80), "seed": float64(123456789)}, readFile(t))
+	})
+
+	// typeSeed opens the seed from the game settings and types text into it
+	typeSeed := func(text string) {
+		press(StartGame, MoveDown, MoveDown, MoveDown, MoveDown, StartGame)
+		for _, ch := range text {
+			g.Handle(keyPress(tcell.KeyRune, ch))
+		}
+	}
+
+	t.Run("the seed is typed in", func(t *testing.T) {
+		setup(t, Config{}, nil)
+
+		typeSeed("-42")
+		require.IsType(t, new(seedEntryState), g.currentState)
+		require.Equal(t, seedEntryViewName, g.ActiveViewName())
+		g.Handle(keyPress(tcell.KeyEnter, 0))
+
+		require.IsType(t, new(settingsState), g.currentState)
+		require.Equal(t, settingsViewName, g.ActiveViewName())
+		require.Equal(t, "Seed: -42", g.settings.view.menu.SelectedItem().Submenu().SelectedItem().Text())
+		press(MoveDown, StartGame)
+		save()
+		require.Equal(t, map[string]any{"seed": float64(-42)}, readFile(t))
+	})
+
+	t.Run("a seed that isn't a number can't be entered", func(t *testing.T) {
+		setup(t, Config{}, nil)
+
+		typeSeed("4x")
+		g.Handle(keyPress(tcell.KeyEnter, 0))
+
+		require.IsType(t, new(seedEntryState), g.currentState)
+		require.Error(t, g.settings.seedEntry.Field().Err())
+	})
+
+	t.Run("escape leaves the seed as it was", func(t *testing.T) {
+		setup(t, Config{seed: 5}, nil)
+
+		typeSeed("0")
+		g.Handle(keyPress(tcell.KeyEscape, 0))
+
+		require.IsType(t, new(settingsState), g.currentState)
+		require.Equal(t, "Seed: 5", g.settings.view.menu.SelectedItem().Submenu().SelectedItem().Text())
+	})
+}
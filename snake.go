@@ -11,6 +11,12 @@ const (
 	startingDir = right
 
 	defaultStartingSnakeMoveDelay = time.Millisecond * 250
+
+	// poisonShrinkage is how many segments a poison apple takes off the snake.
+	poisonShrinkage = 2
+	// speedAppleDuration is how long a speed apple changes how fast the snake moves.
+	speedAppleDuration = 5 * time.Second
+	speedAppleFactor   = 0.5
 )
 
 type direction uint
@@ -37,6 +43,37 @@ func (d direction) String() string {
 	}
 }
 
+// parseDirection is the inverse of direction.String.
+func parseDirection(text string) (direction, error) {
+	for _, d := range []direction{up, right, down, left} {
+		if d.String() == text {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unrecognized direction: %q", text)
+}
+
+// clockwise returns the direction a quarter turn clockwise from d.
+func (d direction) clockwise() direction {
+	return (d + 1) % 4
+}
+
+// offset returns how far a single move in the direction changes a position.
+func (d direction) offset() (dx int, dy int) {
+	switch d {
+	case up:
+		return 0, -1
+	case right:
+		return 1, 0
+	case down:
+		return 0, 1
+	case left:
+		return -1, 0
+	default:
+		return 0, 0
+	}
+}
+
 const (
 	up direction = iota
 	right
@@ -48,9 +85,13 @@ type snake struct {
 	ui.SnakeRenderer
 	moveTimer      time.Duration
 	moveDelay      time.Duration
+	effects        effects
 	lastLength     int
 	startingLength int
+	startDir       direction
 	dir            direction
+	// player is the index of the player controlling the snake.
+	player int
 }
 
 func (s *snake) changeDirection(d direction) {
@@ -59,38 +100,97 @@ func (s *snake) changeDirection(d direction) {
 	}
 }
 
+// bounce turns the snake away from the wall in front of it, clockwise unless that's into
+// another wall, and returns where it moves to instead. A snake with walls on every side can't
+// bounce anywhere.
+func (s *snake) bounce(board *gameBoard) (ui.Position, bool) {
+	for _, d := range []direction{s.dir.clockwise(), s.dir.clockwise().clockwise().clockwise()} {
+		dx, dy := d.offset()
+		if pos := (ui.Position{X: s.head().X + dx, Y: s.head().Y + dy}); board.withinBorder(pos) {
+			s.dir = d
+			return pos, true
+		}
+	}
+	return ui.Position{}, false
+}
+
 func (s *snake) Update(board *gameBoard, g *game, delta time.Duration) {
+	s.effects.update(delta)
 	if !s.canMove(delta) {
 		return
 	}
-	nextPos := s.head()
-	switch s.dir {
-	case up:
-		nextPos.Y--
-	case right:
-		nextPos.X++
-	case down:
-		nextPos.Y++
-	case left:
-		nextPos.X--
+	dx, dy := s.dir.offset()
+	nextPos := ui.Position{X: s.head().X + dx, Y: s.head().Y + dy}
+
+	if !board.withinBorder(nextPos) {
+		switch board.wallMode {
+		case DeadlyWalls:
+			s.collide(board, g)
+			return
+		case WrapWalls:
+			nextPos = board.wrap(nextPos)
+		case BounceWalls:
+			var ok bool
+			if nextPos, ok = s.bounce(board); !ok {
+				return
+			}
+		default:
+			return
+		}
 	}
 
-	if !board.IsInside(nextPos) {
+	o := board.occupied()
+	if o.get(nextPos).wall || s.crashed(o, nextPos) {
+		s.collide(board, g)
 		return
 	}
-
-	if s.crashed(nextPos) {
-		if g.remainingLives -= 1; g.remainingLives > 0 {
-			s.ResetTo(board.Center())
+	if other := o.get(nextPos).owner; other != nil && other != s {
+		// running head first into each other costs both snakes, otherwise only the snake
+		// that ran into the other's body pays
+		if other.head() == nextPos {
+			other.collide(board, g)
 		}
+		s.collide(board, g)
 		return
 	}
 
+	tail := s.Body[0]
 	s.Body = append(s.Body, nextPos)
 	s.Body = s.Body[1:]
+	o.moved(s, tail, nextPos)
+
+	points, eaten := s.eat(o)
+	st := g.statsFor(s)
+	st.score += points
+	st.applesEaten += eaten
+	if p := &board.powerUp; o.get(nextPos).powerUp {
+		kind := p.collect(board)
+		s.effects.add(kind, powerUpDuration(kind))
+	}
+}
 
-	if cnt := s.eat(board.apples); cnt > 0 {
-		g.score += cnt * pointsPerApple
+// collide handles a fatal crash. An active shield absorbs it, leaving the snake where it
+// is, otherwise it costs a life.
+func (s *snake) collide(board *gameBoard, g *game) {
+	if s.effects.consume(ui.ShieldEffect) {
+		return
+	}
+	s.die(board, g)
+}
+
+// die costs the player a life, starting the snake over if any lives remain.
+func (s *snake) die(board *gameBoard, g *game) {
+	if s.player >= firstBot {
+		// bots never run out of lives, they keep competing for the apples
+		s.ResetTo(board.startFor(s))
+		return
+	}
+	st := g.statsFor(s)
+	if st.remainingLives == 0 {
+		return
+	}
+	if st.remainingLives -= 1; st.remainingLives > 0 {
+		s.ResetTo(board.startFor(s))
 	}
 }
 
@@ -99,24 +199,52 @@ func (s *snake) canMove(delta time.Duration) bool {
 	if s.moveTimer > 0 {
 		return false
 	}
-	s.moveTimer = s.moveDelay
+	s.moveTimer = s.currentMoveDelay()
 	return true
 }
 
-func (s *snake) eat(as apples) uint {
-	ret := uint(0)
-	p := s.head()
-	as.ForEach(func(a *apple) {
-		if p == a.Pos {
-			a.eaten = true
-			s.Body = slices.Insert(s.Body, 0, s.Body[0])
-			ret += 1
+// currentMoveDelay returns the time between moves, taking any active effects into account.
+func (s *snake) currentMoveDelay() time.Duration {
+	return time.Duration(float64(s.moveDelay) * s.effects.moveDelayFactor())
+}
+
+// eat eats the apple at the head of the snake, if there is one, applying the effect of its
+// kind. It returns the points scored and how many apples count towards the player's total,
+// which leaves out poison apples. The board's grid, o, is kept up to date.
+func (s *snake) eat(o *occupancy) (points uint, eaten uint) {
+	if a := o.get(s.head()).apple; a != nil {
+		points = a.points()
+		switch a.Kind {
+		case ui.PoisonApple:
+			s.shrink(o, poisonShrinkage)
+		case ui.SpeedApple:
+			s.effects.add(ui.SpeedEffect, speedAppleDuration)
+			fallthrough
+		default:
+			s.grow(o)
+			eaten = 1
 		}
-	})
+		a.eaten = true
+		o.appleMoved(a)
+	}
 	if s.shouldIncreaseSpeed() {
 		s.speedUp()
 	}
-	return ret
+	return points, eaten
+}
+
+// grow adds a segment to the tail of the snake, which fills in behind it on its next move.
+func (s *snake) grow(o *occupancy) {
+	s.Body = slices.Insert(s.Body, 0, s.Body[0])
+	o.grew(s, s.Body[0])
+}
+
+// shrink takes segments off the tail of the snake, always leaving the head.
+func (s *snake) shrink(o *occupancy, n int) {
+	n = min(n, len(s.Body)-1)
+	lost := s.Body[:n]
+	s.Body = s.Body[n:]
+	o.shrank(s, lost...)
 }
 
 func (s *snake) speedUp() {
@@ -132,13 +260,59 @@ func (s *snake) head() ui.Position {
 	return s.Body[len(s.Body)-1]
 }
 
-func (s *snake) crashed(nextPos ui.Position) bool {
-	for i := 0; i < len(s.Body)-2; i += 1 {
-		if nextPos.X == s.Body[i].X && nextPos.Y == s.Body[i].Y {
-			return true
+// crashed reports whether moving to nextPos runs into the snake's body. A ghost passes
+// through its own body.
+func (s *snake) crashed(o *occupancy, nextPos ui.Position) bool {
+	if s.effects.active(ui.GhostEffect) {
+		return false
+	}
+	c := o.get(nextPos)
+	if c.owner != s {
+		return false
+	}
+	// the head and the segment behind it can't be run into, only what's further back
+	n := c.segments
+	for _, p := range s.Body[max(len(s.Body)-2, 0):] {
+		if p == nextPos {
+			n -= 1
+		}
+	}
+	return n > 0
+}
+
+// fitInside moves the whole snake back onto the board if any part of it is outside. When the
+// snake can't fit, it's reset to the board's starting position.
+func (s *snake) fitInside(board *gameBoard) {
+	if !slices.ContainsFunc(s.Body, func(p ui.Position) bool { return !board.IsInside(p) }) {
+		return
+	}
+
+	minPos, maxPos := s.Body[0], s.Body[0]
+	for _, p := range s.Body {
+		minPos.X, minPos.Y = min(minPos.X, p.X), min(minPos.Y, p.Y)
+		maxPos.X, maxPos.Y = max(maxPos.X, p.X), max(maxPos.Y, p.Y)
+	}
+	var dx, dy int
+	if minPos.X <= board.Left() {
+		dx = board.Left() + 1 - minPos.X
+	} else if maxPos.X >= board.Right() {
+		dx = board.Right() - 1 - maxPos.X
+	}
+	if minPos.Y <= board.Top() {
+		dy = board.Top() + 1 - minPos.Y
+	} else if maxPos.Y >= board.Bottom() {
+		dy = board.Bottom() - 1 - maxPos.Y
+	}
+
+	moved := make([]ui.Position, len(s.Body))
+	for i, p := range s.Body {
+		moved[i] = ui.Position{X: p.X + dx, Y: p.Y + dy}
+		if !board.IsInside(moved[i]) {
+			s.ResetTo(board.startFor(s))
+			return
 		}
 	}
-	return false
+	s.Body = moved
 }
 
 func (s *snake) Notify(event Event) {
@@ -164,13 +338,14 @@ func (s *snake) Length() int {
 
 func (s *snake) init(initial ui.Position) {
 	body := make([]ui.Position, 0, 48)
-	zeroBasedCol := initial.X - s.startingLength + 1
-	for range s.startingLength {
-		body = append(body, ui.Position{X: zeroBasedCol, Y: initial.Y})
-		zeroBasedCol += 1
+	dx, dy := s.startDir.offset()
+	for i := s.startingLength - 1; i >= 0; i-- {
+		body = append(body, ui.Position{X: initial.X - i*dx, Y: initial.Y - i*dy})
 	}
 
-	s.dir = startingDir
+	s.dir = s.startDir
+	s.moveTimer = 0
+	s.effects = nil
 	s.moveDelay = defaultStartingSnakeMoveDelay
 	s.lastLength = len(body)
 	s.Body = body
@@ -183,6 +358,7 @@ func newSnake(initial ui.Position) *snake {
 func newSnakeOfLength(initial ui.Position, length int) *snake {
 	ret := snake{
 		startingLength: length,
+		startDir:       startingDir,
 	}
 	ret.init(initial)
 
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"math/rand"
+	"slices"
 	"snake/ui"
 	"testing"
 	"time"
@@ -24,6 +26,7 @@ func Test_Snake(t *testing.T) {
 		}
 		initialPosition = b.Center()
 		s = newSnakeOfLength(initialPosition, startingLength)
+		b.snake = s
 		g = &game{gameBoard: &b}
 	}
 
@@ -320,69 +323,74 @@ func Test_Snake(t *testing.T) {
 		require.False(t, as[1].eaten)
 	})
 
+	// around returns a position relative to the snake's starting position, which is (3, 3).
+	around := func(x, y int) ui.Position {
+		return ui.Position{X: initialPosition.X + x - 3, Y: initialPosition.Y + y - 3}
+	}
+
 	t.Run("snake moving in left circle crashes", func(t *testing.T) {
 		setup()
 		s.Body = []ui.Position{
-			{X: 3, Y: 3},
-			{X: 2, Y: 3},
-			{X: 2, Y: 2},
-			{X: 3, Y: 2},
+			around(3, 3),
+			around(2, 3),
+			around(2, 2),
+			around(3, 2),
 		}
 
-		require.True(t, s.crashed(ui.Position{X: 3, Y: 3}))
+		require.True(t, s.crashed(g.gameBoard.occupied(), around(3, 3)))
 	})
 
 	t.Run("snake moving in right circle crashes", func(t *testing.T) {
 		setup()
 		s.Body = []ui.Position{
-			{X: 3, Y: 3},
-			{X: 4, Y: 3},
-			{X: 4, Y: 2},
-			{X: 3, Y: 2},
+			around(3, 3),
+			around(4, 3),
+			around(4, 2),
+			around(3, 2),
 		}
 
-		require.True(t, s.crashed(ui.Position{X: 3, Y: 3}))
+		require.True(t, s.crashed(g.gameBoard.occupied(), around(3, 3)))
 	})
 
 	t.Run("moving in straight line (right) doesn't crash", func(t *testing.T) {
 		setup()
 		s.Body = []ui.Position{
-			{X: 3, Y: 3},
-			{X: 4, Y: 3},
-			{X: 5, Y: 3},
+			around(3, 3),
+			around(4, 3),
+			around(5, 3),
 		}
 
-		require.False(t, s.crashed(ui.Position{X: 6, Y: 3}))
+		require.False(t, s.crashed(g.gameBoard.occupied(), around(6, 3)))
 	})
 
 	t.Run("moving in straight line (left) doesn't crash", func(t *testing.T) {
 		setup()
 		s.Body = []ui.Position{
-			{X: 3, Y: 3},
-			{X: 2, Y: 3},
+			around(3, 3),
+			around(2, 3),
 		}
 
-		require.False(t, s.crashed(ui.Position{X: 1, Y: 3}))
+		require.False(t, s.crashed(g.gameBoard.occupied(), around(1, 3)))
 	})
 
 	t.Run("moving in straight line (up) doesn't crash", func(t *testing.T) {
 		setup()
 		s.Body = []ui.Position{
-			{X: 3, Y: 3},
-			{X: 3, Y: 2},
+			around(3, 3),
+			around(3, 2),
 		}
 
-		require.False(t, s.crashed(ui.Position{X: 3, Y: 1}))
+		require.False(t, s.crashed(g.gameBoard.occupied(), around(3, 1)))
 	})
 
 	t.Run("moving in straight line (down) doesn't crash", func(t *testing.T) {
 		setup()
 		s.Body = []ui.Position{
-			{X: 3, Y: 3},
-			{X: 3, Y: 4},
+			around(3, 3),
+			around(3, 4),
 		}
 
-		require.False(t, s.crashed(ui.Position{X: 3, Y: 5}))
+		require.False(t, s.crashed(g.gameBoard.occupied(), around(3, 5)))
 	})
 
 	t.Run("reset restores initial state", func(t *testing.T) {
@@ -394,7 +402,7 @@ func Test_Snake(t *testing.T) {
 		}
 		simulate(s, g, MoveUp, MoveUp, MoveRight, MoveLeft)
 
-		pos := ui.Position{1, 1}
+		pos := ui.Position{X: 1, Y: 1}
 		s.ResetTo(pos)
 
 		require.Equal(t, right, s.dir)
@@ -403,6 +411,159 @@ func Test_Snake(t *testing.T) {
 		require.Equal(t, s.startingLength, s.Length())
 	})
 
+	t.Run("apple kinds", func(t *testing.T) {
+		eatKind := func(kind ui.AppleKind) {
+			setup()
+			s.Body = []ui.Position{
+				{X: initialPosition.X - 3, Y: initialPosition.Y},
+				{X: initialPosition.X - 2, Y: initialPosition.Y},
+				{X: initialPosition.X - 1, Y: initialPosition.Y},
+				initialPosition,
+			}
+			g.gameBoard.apples = apples{
+				{AppleRenderer: ui.AppleRenderer{Pos: ui.Position{X: initialPosition.X + 1, Y: initialPosition.Y}, Kind: kind}},
+			}
+			s.Update(g.gameBoard, g, moveDelta)
+		}
+
+		t.Run("normal apples score points and grow the snake", func(t *testing.T) {
+			eatKind(ui.NormalApple)
+
+			require.Equal(t, pointsPerApple, g.score)
+			require.Equal(t, uint(1), g.applesEaten)
+			require.Len(t, s.Body, 5)
+		})
+
+		t.Run("golden apples score bonus points", func(t *testing.T) {
+			eatKind(ui.GoldenApple)
+
+			require.Equal(t, goldenApplePoints, g.score)
+			require.Len(t, s.Body, 5)
+		})
+
+		t.Run("poison apples shrink the snake", func(t *testing.T) {
+			eatKind(ui.PoisonApple)
+
+			require.Equal(t, uint(0), g.score)
+			require.Equal(t, uint(0), g.applesEaten)
+			require.Len(t, s.Body, 4-poisonShrinkage)
+			require.Equal(t, ui.Position{X: initialPosition.X + 1, Y: initialPosition.Y}, s.head())
+		})
+
+		t.Run("poison apples always leave the head", func(t *testing.T) {
+			setup()
+
+			s.shrink(g.gameBoard.occupied(), 5)
+
+			require.Len(t, s.Body, 1)
+		})
+
+		t.Run("speed apples make the snake faster for a while", func(t *testing.T) {
+			eatKind(ui.SpeedApple)
+
+			require.Equal(t, pointsPerApple, g.score)
+			require.Equal(t, time.Duration(float64(s.moveDelay)*speedAppleFactor), s.currentMoveDelay())
+
+			s.effects.update(speedAppleDuration)
+			require.Equal(t, s.moveDelay, s.currentMoveDelay())
+		})
+
+		t.Run("timed apples score bonus points", func(t *testing.T) {
+			eatKind(ui.TimedApple)
+
+			require.Equal(t, timedApplePoints, g.score)
+		})
+	})
+
+	t.Run("power-ups", func(t *testing.T) {
+		// circle moves the snake into its own body.
+		circle := []Event{MoveRight, MoveDown, MoveLeft, MoveUp}
+		setupLong := func() {
+			setup()
+			s = newSnakeOfLength(initialPosition, 5)
+			g.gameBoard.snake = s
+			g.remainingLives = DefaultNumberOfLives
+		}
+
+		t.Run("collecting one grants its effect", func(t *testing.T) {
+			setup()
+			g.gameBoard.powerUp = powerUp{PowerUpRenderer: ui.PowerUpRenderer{
+				Pos: ui.Position{X: initialPosition.X + 1, Y: initialPosition.Y}, Kind: ui.GhostEffect, Visible: true,
+			}}
+			g.gameBoard.rng = rand.New(rand.NewSource(1))
+
+			s.Update(g.gameBoard, g, moveDelta)
+
+			require.True(t, s.effects.active(ui.GhostEffect))
+			require.False(t, g.gameBoard.powerUp.Visible)
+		})
+
+		t.Run("ghost passes through its own body", func(t *testing.T) {
+			setupLong()
+			s.effects.add(ui.GhostEffect, ghostDuration)
+
+			simulate(s, g, circle...)
+
+			require.Equal(t, DefaultNumberOfLives, g.remainingLives)
+		})
+
+		t.Run("ghost wears off", func(t *testing.T) {
+			setupLong()
+			s.effects.add(ui.GhostEffect, moveDelta)
+
+			simulate(s, g, circle...)
+
+			require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+		})
+
+		t.Run("shield absorbs a single crash", func(t *testing.T) {
+			setupLong()
+			s.effects.add(ui.ShieldEffect, shieldDuration)
+
+			simulate(s, g, circle...)
+			require.Equal(t, DefaultNumberOfLives, g.remainingLives)
+			require.False(t, s.effects.active(ui.ShieldEffect))
+
+			simulate(s, g, MoveUp)
+			require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+		})
+
+		t.Run("shield absorbs running into deadly walls", func(t *testing.T) {
+			setup()
+			g.gameBoard.wallMode = DeadlyWalls
+			g.remainingLives = DefaultNumberOfLives
+			s.effects.add(ui.ShieldEffect, shieldDuration)
+			s.Body = []ui.Position{{X: g.gameBoard.Right() - 1, Y: initialPosition.Y}}
+
+			simulate(s, g, MoveRight)
+
+			require.Equal(t, DefaultNumberOfLives, g.remainingLives)
+			require.Equal(t, ui.Position{X: g.gameBoard.Right() - 1, Y: initialPosition.Y}, s.head())
+		})
+
+		t.Run("slow-motion raises the move delay", func(t *testing.T) {
+			setup()
+			s.effects.add(ui.SlowMotionEffect, slowMotionDuration)
+			start := s.head()
+
+			s.Update(g.gameBoard, g, s.moveDelay)
+			s.Update(g.gameBoard, g, s.moveDelay)
+			require.Equal(t, start.X+1, s.head().X)
+
+			s.Update(g.gameBoard, g, s.moveDelay)
+			require.Equal(t, start.X+2, s.head().X)
+		})
+
+		t.Run("losing a life clears effects", func(t *testing.T) {
+			setupLong()
+			s.effects.add(ui.SlowMotionEffect, slowMotionDuration)
+
+			s.die(g.gameBoard, g)
+
+			require.Empty(t, s.effects)
+		})
+	})
+
 	t.Run("speed increases by 25% when doubling in length", func(t *testing.T) {
 		setup()
 		as := apples{
@@ -412,8 +573,9 @@ func Test_Snake(t *testing.T) {
 
 		speed := s.moveDelay
 
+		g.gameBoard.apples = as
 		for range startingLength * 2 {
-			s.eat(as)
+			s.eat(g.gameBoard.occupied())
 		}
 
 		assert.Equal(t, float64(speed)*0.75, float64(s.moveDelay))
@@ -421,6 +583,234 @@ func Test_Snake(t *testing.T) {
 	})
 }
 
+func Test_SnakeWallModes(t *testing.T) {
+	var s *snake
+	var g *game
+	var initialPosition ui.Position
+
+	setup := func(mode WallMode) {
+		b := gameBoard{
+			GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, 9, 9),
+			wallMode:          mode,
+		}
+		initialPosition = b.Center()
+		s = newSnakeOfLength(initialPosition, 1)
+		b.snake = s
+		g = &game{gameBoard: &b, stats: stats{remainingLives: DefaultNumberOfLives}}
+	}
+
+	t.Run("solid", func(t *testing.T) {
+		t.Run("is the default", func(t *testing.T) {
+			var cfg Config
+			require.Equal(t, SolidWalls, cfg.WallMode())
+		})
+
+		t.Run("stops the snake without costing a life", func(t *testing.T) {
+			setup(SolidWalls)
+
+			simulate(s, g, MoveRight, MoveRight, MoveRight, MoveRight, MoveRight)
+
+			require.Equal(t, ui.Position{X: g.gameBoard.Right() - 1, Y: initialPosition.Y}, s.head())
+			require.Equal(t, DefaultNumberOfLives, g.remainingLives)
+		})
+	})
+
+	t.Run("deadly", func(t *testing.T) {
+		t.Run("running into a wall costs a life", func(t *testing.T) {
+			setup(DeadlyWalls)
+
+			simulate(s, g, MoveRight, MoveRight, MoveRight, MoveRight)
+
+			require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+			require.Equal(t, g.gameBoard.Center(), s.head())
+			require.Equal(t, right, s.dir)
+		})
+
+		t.Run("every wall is deadly", func(t *testing.T) {
+			for _, event := range []Event{MoveUp, MoveDown} {
+				setup(DeadlyWalls)
+
+				simulate(s, g, event, event)
+
+				require.Equal(t, DefaultNumberOfLives-1, g.remainingLives, "moving %v", event)
+			}
+			setup(DeadlyWalls)
+			simulate(s, g, MoveUp, MoveLeft, MoveLeft, MoveLeft, MoveLeft)
+			require.Equal(t, DefaultNumberOfLives-1, g.remainingLives, "moving left")
+		})
+
+		t.Run("running into a wall on the last life ends the game", func(t *testing.T) {
+			setup(DeadlyWalls)
+			g.remainingLives = 1
+
+			simulate(s, g, MoveRight, MoveRight, MoveRight, MoveRight)
+
+			require.True(t, g.gameOver())
+		})
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		t.Run("right edge wraps to left edge", func(t *testing.T) {
+			setup(WrapWalls)
+
+			simulate(s, g, MoveRight, MoveRight, MoveRight, MoveRight)
+
+			require.Equal(t, ui.Position{X: g.gameBoard.Left() + 1, Y: initialPosition.Y}, s.head())
+			require.Equal(t, DefaultNumberOfLives, g.remainingLives)
+		})
+
+		t.Run("left edge wraps to right edge", func(t *testing.T) {
+			setup(WrapWalls)
+
+			simulate(s, g, MoveUp, MoveLeft, MoveLeft, MoveLeft, MoveLeft)
+
+			require.Equal(t, ui.Position{X: g.gameBoard.Right() - 1, Y: initialPosition.Y - 1}, s.head())
+		})
+
+		t.Run("top edge wraps to bottom edge", func(t *testing.T) {
+			setup(WrapWalls)
+
+			simulate(s, g, MoveUp, MoveUp)
+
+			require.Equal(t, ui.Position{X: initialPosition.X, Y: g.gameBoard.Bottom() - 1}, s.head())
+		})
+
+		t.Run("bottom edge wraps to top edge", func(t *testing.T) {
+			setup(WrapWalls)
+
+			simulate(s, g, MoveDown, MoveDown)
+
+			require.Equal(t, ui.Position{X: initialPosition.X, Y: g.gameBoard.Top() + 1}, s.head())
+		})
+
+		t.Run("wrapping into its own body costs a life", func(t *testing.T) {
+			setup(WrapWalls)
+			left := g.gameBoard.Left() + 1
+			right := g.gameBoard.Right() - 1
+			y := initialPosition.Y
+			s.Body = []ui.Position{{X: left, Y: y}, {X: left + 1, Y: y}, {X: right - 1, Y: y}, {X: right, Y: y}}
+
+			simulate(s, g, MoveRight)
+
+			require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+		})
+	})
+
+	t.Run("bounce", func(t *testing.T) {
+		t.Run("turns clockwise along the wall", func(t *testing.T) {
+			setup(BounceWalls)
+
+			simulate(s, g, MoveRight, MoveRight, MoveRight, MoveRight)
+
+			require.Equal(t, ui.Position{X: g.gameBoard.Right() - 1, Y: initialPosition.Y + 1}, s.head())
+			require.Equal(t, down, s.dir)
+			require.Equal(t, DefaultNumberOfLives, g.remainingLives)
+		})
+
+		t.Run("turns the other way in a corner", func(t *testing.T) {
+			setup(BounceWalls)
+			corner := ui.Position{X: g.gameBoard.Right() - 1, Y: g.gameBoard.Top() + 1}
+			s.Body = []ui.Position{corner}
+			s.dir = up
+
+			simulate(s, g, MoveUp)
+
+			require.Equal(t, ui.Position{X: corner.X - 1, Y: corner.Y}, s.head())
+			require.Equal(t, left, s.dir)
+		})
+
+		t.Run("bouncing into its own body costs a life", func(t *testing.T) {
+			setup(BounceWalls)
+			edge := g.gameBoard.Right() - 1
+			y := initialPosition.Y
+			s.Body = []ui.Position{{X: edge, Y: y + 2}, {X: edge, Y: y + 1}, {X: edge - 1, Y: y + 1}, {X: edge - 1, Y: y}, {X: edge, Y: y}}
+			s.dir = right
+
+			simulate(s, g, MoveRight)
+
+			require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+		})
+	})
+}
+
+func Test_SnakeObstacles(t *testing.T) {
+	newLevelGame := func(t *testing.T, cfg *Config) *game {
+		g := newSnakeGame(cfg, 30, 30)
+		require.NoError(t, g.setLevel(loadTestLevel(t, "direction: up")))
+		g.reset()
+		return g
+	}
+
+	for _, mode := range wallModes {
+		t.Run("running into a wall costs a life with "+mode.String()+" walls", func(t *testing.T) {
+			g := newLevelGame(t, &Config{wallMode: mode})
+			s := g.gameBoard.snake
+
+			// The wall is three rows above the start.
+			simulate(s, g, MoveUp, MoveUp)
+			require.Equal(t, DefaultNumberOfLives, g.remainingLives)
+
+			simulate(s, g, MoveUp)
+			require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+			require.Equal(t, g.gameBoard.start(), s.head())
+			require.Equal(t, up, s.dir)
+		})
+	}
+
+	t.Run("wrapping onto a wall costs a life", func(t *testing.T) {
+		g := newLevelGame(t, &Config{wallMode: WrapWalls})
+		s := g.gameBoard.snake
+		// Line the snake up under the wall, so it hits it after wrapping from the bottom.
+		bottom := g.gameBoard.fromLevel(ui.Position{X: 9, Y: 8})
+		s.Body = []ui.Position{{X: bottom.X, Y: bottom.Y - 2}, {X: bottom.X, Y: bottom.Y - 1}, bottom}
+		s.dir = down
+
+		simulate(s, g, MoveDown)
+		require.Equal(t, g.gameBoard.fromLevel(ui.Position{X: 9, Y: 0}), s.head())
+		require.Equal(t, DefaultNumberOfLives, g.remainingLives)
+
+		simulate(s, g, MoveDown)
+		require.Equal(t, DefaultNumberOfLives-1, g.remainingLives)
+	})
+}
+
+func Test_SnakeFitInside(t *testing.T) {
+	board := &gameBoard{
+		GameBoardRenderer: ui.NewGameBoardRenderer(ui.Position{X: 0, Y: 0}, 10, 10),
+	}
+
+	t.Run("snake on the board doesn't move", func(t *testing.T) {
+		s := newSnake(board.Center())
+		exp := slices.Clone(s.Body)
+
+		s.fitInside(board)
+
+		require.Equal(t, exp, s.Body)
+	})
+
+	t.Run("snake is shifted back onto the board keeping its shape", func(t *testing.T) {
+		s := newSnake(board.Center())
+		s.Body = []ui.Position{{X: 8, Y: 9}, {X: 9, Y: 9}, {X: 10, Y: 9}}
+
+		s.fitInside(board)
+
+		require.Equal(t, []ui.Position{{X: 6, Y: 8}, {X: 7, Y: 8}, {X: 8, Y: 8}}, s.Body)
+	})
+
+	t.Run("snake that can't fit is reset to the center", func(t *testing.T) {
+		s := newSnake(board.Center())
+		s.Body = nil
+		for x := range 12 {
+			s.Body = append(s.Body, ui.Position{X: x, Y: 6})
+		}
+
+		s.fitInside(board)
+
+		require.Equal(t, board.Center(), s.head())
+		require.Equal(t, DefaultStartingLength, s.Length())
+	})
+}
+
 func simulate(s *snake, g *game, events ...Event) {
 	for _, event := range events {
 		s.Notify(event)
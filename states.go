@@ -1,11 +1,25 @@
 package main
 
-import "time"
+import (
+	"fmt"
+	"snake/ui"
+	"time"
+)
 
 const (
 	GameOverText            = "Game Over"
+	GameOverSeedFormat      = GameOverText + " (seed %d)"
+	WinnerSeedFormat        = "%s (seed %d)"
+	PlayerOneWinsText       = "Player One Wins"
+	PlayerTwoWinsText       = "Player Two Wins"
+	DrawText                = "Draw"
+	BoardClearedText        = "Board Cleared"
+	BoardClearedSeedFormat  = BoardClearedText + " (seed %d)"
 	GamePausedText          = "Game Paused"
+	LevelCompleteText       = "Level Complete"
+	CampaignCompleteText    = "Campaign Complete"
 	MainMenuTransitionDelay = 2 * time.Second
+	LevelTransitionDelay    = 2 * time.Second
 )
 
 type state interface {
@@ -19,14 +33,28 @@ type playingState struct {
 
 func (p *playingState) update(g *game, delta time.Duration) {
 	p.board.Update(g, delta)
+	g.playTime += delta
+	if g.gameBoard.cleared() {
+		g.currentState = &boardClearedState{delay: MainMenuTransitionDelay}
+		return
+	}
 	if g.gameOver() {
 		g.currentState = &gameOverState{delay: MainMenuTransitionDelay}
+		return
+	}
+	if g.campaign != nil {
+		goal := g.campaign.level().Goal
+		p.board.SetGoal(goal.status(g.goalProgress()))
+		if goal.met(g.goalProgress()) {
+			g.completeLevel()
+			g.currentState = &levelCompleteState{delay: LevelTransitionDelay}
+		}
 	}
 }
 
 func (p *playingState) handle(g *game, event Event) {
 	if event == PauseGame {
-		g.currentState = &pausedState{currentGame: p}
+		g.pause()
 	}
 }
 
@@ -35,10 +63,14 @@ type gameOverState struct {
 }
 
 func (gos *gameOverState) update(g *game, delta time.Duration) {
-	g.Manager.ShowModal(GameOverText)
+	if g.twoPlayer() {
+		g.Manager.ShowModal(fmt.Sprintf(WinnerSeedFormat, g.winner(), g.seed))
+	} else {
+		g.Manager.ShowModal(fmt.Sprintf(GameOverSeedFormat, g.seed))
+	}
 	if gos.delay -= delta; gos.delay <= 0 {
 		g.Manager.HideModal()
-		g.currentState = new(menuState)
+		g.currentState = g.afterGame()
 	}
 }
 
@@ -46,6 +78,76 @@ func (gos *gameOverState) handle(*game, Event) {
 	// do nothing
 }
 
+// boardClearedState announces that the snake filled the whole board, which wins the game.
+type boardClearedState struct {
+	delay time.Duration
+}
+
+func (b *boardClearedState) update(g *game, delta time.Duration) {
+	g.Manager.ShowModal(fmt.Sprintf(BoardClearedSeedFormat, g.seed))
+	if b.delay -= delta; b.delay <= 0 {
+		g.Manager.HideModal()
+		g.currentState = g.afterGame()
+	}
+}
+
+func (b *boardClearedState) handle(*game, Event) {
+	// do nothing
+}
+
+// textEntryState is a state where keys are typed into a text field instead of steering the
+// game. Escape cancels the entry.
+type textEntryState interface {
+	state
+	cancel(g *game)
+}
+
+// nameEntryState waits for the player to type their name for a new high score. The keys go
+// straight to the name entry view, which submits the name when Enter is pressed.
+type nameEntryState struct{}
+
+func (n *nameEntryState) update(*game, time.Duration) {
+	// do nothing
+}
+
+func (n *nameEntryState) handle(*game, Event) {
+	// do nothing
+}
+
+// cancel records the score without a name.
+func (n *nameEntryState) cancel(g *game) {
+	g.submitHighScore("")
+}
+
+// seedEntryState waits for the player to type a seed on the settings screen. Like the name
+// entry, the keys go straight to the field.
+type seedEntryState struct{}
+
+func (s *seedEntryState) update(*game, time.Duration) {
+	// do nothing
+}
+
+func (s *seedEntryState) handle(*game, Event) {
+	// do nothing
+}
+
+func (s *seedEntryState) cancel(g *game) {
+	g.leaveSeedEntry()
+}
+
+// highScoresState shows the high-score table until the player presses Enter.
+type highScoresState struct{}
+
+func (h *highScoresState) update(*game, time.Duration) {
+	// do nothing
+}
+
+func (h *highScoresState) handle(g *game, event Event) {
+	if event == StartGame {
+		g.currentState = g.menu()
+	}
+}
+
 type pausedState struct {
 	currentGame *playingState
 }
@@ -61,16 +163,113 @@ func (p *pausedState) handle(g *game, event Event) {
 	}
 }
 
+// menuState shows the main menu, which either player can move through.
 type menuState struct{}
 
-func (m *menuState) update(g *game, _ time.Duration) {
-	g.Manager.ShowModal("Press enter to start...")
+func (m *menuState) update(*game, time.Duration) {
+	// do nothing
 }
 
 func (m *menuState) handle(g *game, event Event) {
-	if event == StartGame {
-		g.reset()
+	steer(g.mainMenu.menu, event)
+}
+
+// settingsState shows the settings screen. Going back out of it leaves the settings unsaved.
+type settingsState struct{}
+
+func (s *settingsState) update(*game, time.Duration) {
+	// do nothing
+}
+
+func (s *settingsState) handle(g *game, event Event) {
+	if g.ModalVisible() {
+		// any key dismisses why the settings weren't saved
+		g.HideModal()
+		return
+	}
+	if !steer(g.settings.view.menu, event) {
+		g.currentState = g.menu()
+	}
+}
+
+// steer moves through a menu with either player's keys, reporting false when Left or Escape is
+// pressed with nothing to step or go back out of.
+func steer(menu *ui.Menu, event Event) bool {
+	switch _, event = forPlayer(event); event {
+	case MoveUp:
+		menu.SelectPrevious()
+	case MoveDown:
+		menu.SelectNext()
+	case MoveLeft:
+		return menu.Adjust(-1) || menu.Back()
+	case MoveRight:
+		menu.Adjust(1)
+	case Back:
+		return menu.Back()
+	case StartGame:
+		menu.Activate()
+	}
+	return true
+}
+
+// levelSelectState lets the player pick which unlocked level of a campaign to play.
+type levelSelectState struct{}
+
+func (l *levelSelectState) update(*game, time.Duration) {
+	// do nothing
+}
+
+func (l *levelSelectState) handle(g *game, event Event) {
+	r := g.campaign
+	switch event {
+	case MoveUp:
+		r.view.Select(r.view.Selected() - 1)
+	case MoveDown:
+		r.view.Select(r.view.Selected() + 1)
+	case StartGame:
+		if i := r.view.Selected(); r.progress.unlocked(r.campaign, i) {
+			g.startLevel(i)
+		}
+	}
+}
+
+// levelCompleteState announces that the goal of a campaign level was met, before moving
+// on to the next level.
+type levelCompleteState struct {
+	delay time.Duration
+}
+
+func (l *levelCompleteState) update(g *game, delta time.Duration) {
+	g.Manager.ShowModal(LevelCompleteText)
+	if l.delay -= delta; l.delay > 0 {
+		return
+	}
+	g.Manager.HideModal()
+	if next := g.campaign.current + 1; next < len(g.campaign.campaign.Levels) {
+		g.startLevel(next)
+	} else {
+		g.currentState = &campaignCompleteState{delay: MainMenuTransitionDelay}
+	}
+}
+
+func (l *levelCompleteState) handle(*game, Event) {
+	// do nothing
+}
+
+// campaignCompleteState announces that the last level of a campaign was completed, before
+// returning to the level select.
+type campaignCompleteState struct {
+	delay time.Duration
+}
+
+func (c *campaignCompleteState) update(g *game, delta time.Duration) {
+	g.Manager.ShowModal(CampaignCompleteText)
+	if c.delay -= delta; c.delay <= 0 {
 		g.Manager.HideModal()
-		g.currentState = &playingState{board: g.gameBoard}
+		g.currentState = g.menu()
 	}
 }
+
+func (c *campaignCompleteState) handle(*game, Event) {
+	// do nothing
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"snake/ui"
 	"testing"
 	"time"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,12 +24,100 @@ func Test_States(t *testing.T) {
 			require.IsType(t, new(playingState), g.currentState)
 		})
 
-		t.Run("shows modal for menu text", func(t *testing.T) {
+		t.Run("shows the main menu", func(t *testing.T) {
 			setup()
 
 			g.Update(time.Millisecond * 500)
 
-			require.True(t, g.Manager.ModalVisible())
+			require.Equal(t, mainMenuViewName, g.ActiveViewName())
+			require.False(t, g.Manager.ModalVisible())
+		})
+
+		t.Run("moves through the enabled entries", func(t *testing.T) {
+			setup()
+			menu := g.mainMenu.menu
+
+			g.currentState.handle(g, MoveDown)
+			require.Equal(t, g.mainMenu.mode, menu.SelectedItem())
+
+			// settings and high scores are disabled, so they're skipped
+			g.currentState.handle(g, MoveDown)
+			require.Equal(t, "Quit", menu.SelectedItem().Text())
+
+			g.currentState.handle(g, MoveUp)
+			g.currentState.handle(g, MoveUp)
+			require.Equal(t, "Play", menu.SelectedItem().Text())
+		})
+
+		t.Run("quits from the menu", func(t *testing.T) {
+			setup()
+
+			g.currentState.handle(g, MoveUp)
+			g.currentState.handle(g, StartGame)
+
+			require.True(t, g.Finished())
+		})
+
+		t.Run("changes the wall mode in a submenu", func(t *testing.T) {
+			setup()
+
+			g.currentState.handle(g, MoveDown)
+			g.currentState.handle(g, StartGame)
+			g.currentState.handle(g, MoveDown)
+			g.currentState.handle(g, MoveDown)
+			g.currentState.handle(g, StartGame)
+
+			require.Equal(t, WrapWalls, g.gameBoard.wallMode)
+			require.Equal(t, "Mode: wrap", g.mainMenu.mode.Text())
+			g.currentState.handle(g, MoveUp)
+			g.currentState.handle(g, StartGame)
+			require.IsType(t, new(playingState), g.currentState, "the submenu closes once a mode is picked")
+		})
+
+		t.Run("backs out of a submenu", func(t *testing.T) {
+			setup()
+
+			g.currentState.handle(g, MoveDown)
+			g.currentState.handle(g, StartGame)
+			g.currentState.handle(g, MoveLeft)
+			g.currentState.handle(g, MoveUp)
+			g.currentState.handle(g, StartGame)
+
+			require.IsType(t, new(playingState), g.currentState)
+			require.Equal(t, SolidWalls, g.gameBoard.wallMode)
+		})
+
+		t.Run("escape backs out of a submenu", func(t *testing.T) {
+			setup()
+
+			g.currentState.handle(g, MoveDown)
+			g.currentState.handle(g, StartGame)
+			g.Handle(keyPress(tcell.KeyEscape, 0))
+			g.currentState.handle(g, MoveUp)
+			g.currentState.handle(g, StartGame)
+
+			require.IsType(t, new(playingState), g.currentState)
+			require.Equal(t, SolidWalls, g.gameBoard.wallMode)
+		})
+
+		t.Run("other letters don't move the highlight", func(t *testing.T) {
+			setup()
+
+			g.Handle(keyPress(tcell.KeyRune, 'x'))
+
+			require.Equal(t, "Play", g.mainMenu.menu.SelectedItem().Text())
+		})
+
+		t.Run("shows the high scores once there's a table", func(t *testing.T) {
+			setup()
+			g.setHighScores(&HighScores{}, "")
+
+			g.currentState.handle(g, MoveDown)
+			g.currentState.handle(g, MoveDown)
+			g.currentState.handle(g, StartGame)
+
+			require.IsType(t, new(highScoresState), g.currentState)
+			require.Equal(t, ui.HighScoresViewName, g.ActiveViewName())
 		})
 	})
 
@@ -54,6 +144,91 @@ func Test_States(t *testing.T) {
 		})
 	})
 
+	t.Run("board cleared", func(t *testing.T) {
+		t.Run("wins the game when the snake fills the board", func(t *testing.T) {
+			setup()
+			g.currentState.handle(g, StartGame)
+			g.gameBoard.apples = nil
+			g.gameBoard.snake.Body = nil
+			g.gameBoard.snake.Body = g.gameBoard.freeCells()
+
+			g.Update(TickDuration)
+
+			require.IsType(t, new(boardClearedState), g.currentState)
+			g.Update(TickDuration)
+			require.True(t, g.Manager.ModalVisible())
+		})
+
+		t.Run("returns to the menu", func(t *testing.T) {
+			setup()
+			g.currentState = &boardClearedState{delay: time.Millisecond}
+
+			g.Update(TickDuration)
+
+			require.IsType(t, new(menuState), g.currentState)
+		})
+	})
+
+	t.Run("resizing", func(t *testing.T) {
+		t.Run("pauses when the board no longer fits", func(t *testing.T) {
+			setup()
+			g.currentState.handle(g, StartGame)
+
+			g.Handle(tcell.NewEventResize(g.gameBoard.Width()-1, g.gameBoard.Height()))
+
+			require.IsType(t, new(pausedState), g.currentState)
+		})
+
+		t.Run("keeps playing when the screen grows", func(t *testing.T) {
+			setup()
+			g.currentState.handle(g, StartGame)
+
+			g.Handle(tcell.NewEventResize(g.gameBoard.Width()+5, g.gameBoard.Height()+5))
+
+			require.IsType(t, new(playingState), g.currentState)
+		})
+
+		t.Run("too small screen shows guard until large enough", func(t *testing.T) {
+			setup()
+			g.currentState.handle(g, StartGame)
+
+			g.Handle(tcell.NewEventResize(minWidth-1, minHeight))
+			require.Equal(t, ui.TooSmallViewName, g.ActiveViewName())
+
+			g.Handle(tcell.NewEventResize(minWidth, minHeight))
+			require.Equal(t, "GameBoard", g.ActiveViewName())
+			require.IsType(t, new(pausedState), g.currentState)
+		})
+
+		t.Run("game doesn't update or take input while too small", func(t *testing.T) {
+			setup()
+			g.Handle(tcell.NewEventResize(minWidth-1, minHeight-1))
+
+			g.Handle(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+			g.Update(time.Millisecond * 500)
+
+			require.IsType(t, new(menuState), g.currentState)
+			require.Zero(t, g.ticks)
+		})
+
+		t.Run("board never shrinks below minimum size", func(t *testing.T) {
+			setup()
+
+			g.Handle(tcell.NewEventResize(1, 1))
+
+			require.Equal(t, minWidth, g.gameBoard.Width())
+			require.Equal(t, minHeight, g.gameBoard.Height())
+		})
+
+		t.Run("menu isn't paused", func(t *testing.T) {
+			setup()
+
+			g.Handle(tcell.NewEventResize(g.gameBoard.Width()-1, g.gameBoard.Height()-1))
+
+			require.IsType(t, new(menuState), g.currentState)
+		})
+	})
+
 	t.Run("paused state", func(t *testing.T) {
 		t.Run("transitions to playing on PauseEvent", func(t *testing.T) {
 			setup()
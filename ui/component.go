@@ -23,6 +23,11 @@ type EventHandler interface {
 	Handle(tcell.Event)
 }
 
+// Resizable is implemented by components whose geometry depends on the size of the screen.
+type Resizable interface {
+	Resize(width, height int)
+}
+
 type leaf struct{}
 
 func (l leaf) Add(Component) error {
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// effectBarWidth is the number of cells in an effect's countdown bar.
+	effectBarWidth     = 4
+	effectBarFullRune  = tcell.RuneBlock
+	effectBarEmptyRune = '-'
+)
+
+// EffectKind is a temporary ability of the snake, usually granted by a power-up.
+type EffectKind int
+
+const (
+	SlowMotionEffect EffectKind = iota
+	GhostEffect
+	ShieldEffect
+	SpeedEffect
+)
+
+// PowerUpKinds lists the effects that can be picked up from the board.
+var PowerUpKinds = []EffectKind{SlowMotionEffect, GhostEffect, ShieldEffect}
+
+func (k EffectKind) String() string {
+	switch k {
+	case SlowMotionEffect:
+		return "slow-motion"
+	case GhostEffect:
+		return "ghost"
+	case ShieldEffect:
+		return "shield"
+	case SpeedEffect:
+		return "speed"
+	default:
+		return fmt.Sprintf("unrecognized effect: %d", int(k))
+	}
+}
+
+// EffectStatus is an active effect as shown in the Hud. Remaining is the fraction of the
+// effect's time that's left, from 0 to 1.
+type EffectStatus struct {
+	Kind      EffectKind
+	Remaining float64
+}
+
+// PowerUpRenderer draws a power-up when it's on the board.
+type PowerUpRenderer struct {
+	leaf
+	Pos     Position
+	Kind    EffectKind
+	Visible bool
+}
+
+func (p *PowerUpRenderer) Draw(scn tcell.Screen) {
+	if p.Visible {
+		scn.SetContent(p.Pos.X, p.Pos.Y, effectRunes[p.Kind], nil, styles[effectStyles[p.Kind]])
+	}
+}
+
+func (p *PowerUpRenderer) Width() int {
+	return 1
+}
+
+func (p *PowerUpRenderer) Height() int {
+	return 1
+}
+
+// drawEffects draws each effect as its rune followed by a countdown bar, ending at the
+// given position.
+func drawEffects(effects []EffectStatus, right Position, scrn tcell.Screen) {
+	// each effect takes its rune and bar, with a space between effects
+	x := right.X - len(effects)*(effectBarWidth+2) + 2
+	for _, e := range effects {
+		style := styles[effectStyles[e.Kind]]
+		scrn.SetContent(x, right.Y, effectRunes[e.Kind], nil, style)
+		full := int(e.Remaining*effectBarWidth + 0.5)
+		for i := range effectBarWidth {
+			r := effectBarEmptyRune
+			if i < full {
+				r = effectBarFullRune
+			}
+			scrn.SetContent(x+1+i, right.Y, r, nil, style)
+		}
+		x += effectBarWidth + 2
+	}
+}
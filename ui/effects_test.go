@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PowerUpRenderer(t *testing.T) {
+	t.Run("draws its kind when visible", func(t *testing.T) {
+		scrn := setup(t)
+		p := PowerUpRenderer{Pos: Position{X: 2, Y: 3}, Kind: ShieldEffect, Visible: true}
+
+		p.Draw(scrn)
+
+		requireEqualContents(t, 2, 3, shieldRune, scrn)
+	})
+
+	t.Run("draws nothing when hidden", func(t *testing.T) {
+		scrn := setup(t)
+		p := PowerUpRenderer{Pos: Position{X: 2, Y: 3}, Kind: ShieldEffect}
+
+		p.Draw(scrn)
+
+		requireEqualContents(t, 2, 3, ' ', scrn)
+	})
+}
+
+func Test_HudEffects(t *testing.T) {
+	const width = 20
+	scrn := setupScreen(t, width, 3)
+	hud := NewHud(Position{X: 0, Y: 0}, 0, width)
+
+	hud.SetEffects([]EffectStatus{
+		{Kind: GhostEffect, Remaining: 0.5},
+		{Kind: ShieldEffect, Remaining: 1},
+	})
+	hud.Draw(scrn)
+
+	_, y := hud.ScoreBox().Position()
+	expect := []rune{
+		ghostRune, effectBarFullRune, effectBarFullRune, effectBarEmptyRune, effectBarEmptyRune, ' ',
+		shieldRune, effectBarFullRune, effectBarFullRune, effectBarFullRune, effectBarFullRune,
+	}
+	for i, r := range expect {
+		requireEqualContents(t, width-len(expect)+i, y, r, scrn)
+	}
+	requireEqualContents(t, 0, y, 'S', scrn)
+	require.Len(t, hud.Effects(), 2)
+}
@@ -1,44 +1,122 @@
 package ui
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/gdamore/tcell/v2"
 )
 
 const (
-	appleRune = 'A'
 	snakeRune = 'X'
+	wallRune  = tcell.RuneCkBoard
 )
 
+// AppleKind decides how an apple is drawn and what eating it does.
+type AppleKind int
+
+const (
+	NormalApple AppleKind = iota
+	GoldenApple
+	PoisonApple
+	SpeedApple
+	TimedApple
+)
+
+// AppleKinds lists every kind of apple.
+var AppleKinds = []AppleKind{NormalApple, GoldenApple, PoisonApple, SpeedApple, TimedApple}
+
+func (k AppleKind) String() string {
+	switch k {
+	case NormalApple:
+		return "normal"
+	case GoldenApple:
+		return "golden"
+	case PoisonApple:
+		return "poison"
+	case SpeedApple:
+		return "speed"
+	case TimedApple:
+		return "timed"
+	default:
+		return fmt.Sprintf("unrecognized apple kind: %d", int(k))
+	}
+}
+
+func (k AppleKind) MarshalText() ([]byte, error) {
+	if !slices.Contains(AppleKinds, k) {
+		return nil, fmt.Errorf("unrecognized apple kind: %d", int(k))
+	}
+	return []byte(k.String()), nil
+}
+
+func (k *AppleKind) UnmarshalText(text []byte) error {
+	for _, kind := range AppleKinds {
+		if kind.String() == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized apple kind: %q", text)
+}
+
 type SnakeRenderer struct {
 	leaf
 	Body []Position
+	// Player picks the colour of the snake, so players can tell their snakes apart.
+	Player int
 }
 
 func (s *SnakeRenderer) Draw(scrn tcell.Screen) {
+	style := styles[snakeStyles[min(s.Player, len(snakeStyles)-1)]]
 	for _, c := range slices.All(s.Body) {
-		scrn.SetContent(c.X, c.Y, snakeRune, nil, styles[snakeStyle])
+		scrn.SetContent(c.X, c.Y, snakeRune, nil, style)
 	}
 }
 
+// Width returns the number of columns spanned by the snake.
 func (s *SnakeRenderer) Width() int {
-	//TODO implement me
-	panic("implement me")
+	return spanX(s.Body)
 }
 
+// Height returns the number of rows spanned by the snake.
 func (s *SnakeRenderer) Height() int {
-	//TODO implement me
-	panic("implement me")
+	return spanY(s.Body)
+}
+
+// spanX returns the number of columns between the leftmost and rightmost positions.
+func spanX(ps []Position) int {
+	if len(ps) == 0 {
+		return 0
+	}
+	minX := slices.MinFunc(ps, func(a, b Position) int { return a.X - b.X }).X
+	maxX := slices.MaxFunc(ps, func(a, b Position) int { return a.X - b.X }).X
+	return maxX - minX + 1
+}
+
+// spanY returns the number of rows between the topmost and bottommost positions.
+func spanY(ps []Position) int {
+	if len(ps) == 0 {
+		return 0
+	}
+	minY := slices.MinFunc(ps, func(a, b Position) int { return a.Y - b.Y }).Y
+	maxY := slices.MaxFunc(ps, func(a, b Position) int { return a.Y - b.Y }).Y
+	return maxY - minY + 1
 }
 
 type AppleRenderer struct {
 	leaf
-	Pos Position
+	Pos  Position
+	Kind AppleKind
+	// Hidden apples aren't drawn, which is how apples are taken off a board with no room left.
+	Hidden bool
 }
 
 func (a *AppleRenderer) Draw(scn tcell.Screen) {
-	scn.SetContent(a.Pos.X, a.Pos.Y, appleRune, nil, styles[foodStyle])
+	if a.Hidden {
+		return
+	}
+	scn.SetContent(a.Pos.X, a.Pos.Y, appleRunes[a.Kind], nil, styles[appleStyles[a.Kind]])
 }
 
 func (a *AppleRenderer) Width() int {
@@ -48,3 +126,51 @@ func (a *AppleRenderer) Width() int {
 func (a *AppleRenderer) Height() int {
 	return 1
 }
+
+// WallRenderer draws the obstacles of a level.
+type WallRenderer struct {
+	leaf
+	Tiles []Position
+}
+
+func (w *WallRenderer) Draw(scn tcell.Screen) {
+	for _, t := range w.Tiles {
+		scn.SetContent(t.X, t.Y, wallRune, nil, styles[wallStyle])
+	}
+}
+
+// Width returns the number of columns spanned by the walls.
+func (w *WallRenderer) Width() int {
+	return spanX(w.Tiles)
+}
+
+// Height returns the number of rows spanned by the walls.
+func (w *WallRenderer) Height() int {
+	return spanY(w.Tiles)
+}
+
+// TrailRenderer draws a faint trail across the board, such as the path suggested to a player.
+type TrailRenderer struct {
+	leaf
+	Cells   []Position
+	Visible bool
+}
+
+func (t *TrailRenderer) Draw(scn tcell.Screen) {
+	if !t.Visible {
+		return
+	}
+	for _, c := range t.Cells {
+		scn.SetContent(c.X, c.Y, trailRune, nil, styles[trailStyle])
+	}
+}
+
+// Width returns the number of columns spanned by the trail.
+func (t *TrailRenderer) Width() int {
+	return spanX(t.Cells)
+}
+
+// Height returns the number of rows spanned by the trail.
+func (t *TrailRenderer) Height() int {
+	return spanY(t.Cells)
+}
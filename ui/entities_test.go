@@ -27,6 +27,90 @@ func Test_EntityRendering(t *testing.T) {
 		}
 	})
 
+	t.Run("second player's snake has its own colour", func(t *testing.T) {
+		dst := setup(t)
+
+		s := SnakeRenderer{Body: []Position{{X: 1, Y: 1}}, Player: 1}
+		s.Draw(dst)
+
+		_, _, style, _ := dst.GetContent(1, 1)
+		if style != styles[snakeTwoStyle] {
+			t.Errorf("expected the second player's style, got %v", style)
+		}
+	})
+
+	t.Run("snake dimensions span its body", func(t *testing.T) {
+		s := SnakeRenderer{
+			Body: []Position{{X: 1, Y: 2}, {X: 1, Y: 3}, {X: 2, Y: 3}, {X: 3, Y: 3}},
+		}
+
+		if s.Width() != 3 || s.Height() != 2 {
+			t.Errorf("expected 3x2, got %dx%d", s.Width(), s.Height())
+		}
+	})
+
+	t.Run("walls", func(t *testing.T) {
+		scn := setup(t)
+
+		w := WallRenderer{
+			Tiles: []Position{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 4, Y: 3}},
+		}
+		w.Draw(scn)
+
+		for _, c := range w.Tiles {
+			requireEqualContents(t, c.X, c.Y, wallRune, scn)
+		}
+		if w.Width() != 4 || w.Height() != 3 {
+			t.Errorf("expected 4x3, got %dx%d", w.Width(), w.Height())
+		}
+	})
+
+	t.Run("trail", func(t *testing.T) {
+		scn := setup(t)
+
+		tr := TrailRenderer{Cells: []Position{{X: 1, Y: 1}, {X: 1, Y: 2}}}
+		tr.Draw(scn)
+		requireEqualContents(t, 1, 1, ' ', scn)
+
+		tr.Visible = true
+		tr.Draw(scn)
+		for _, c := range tr.Cells {
+			requireEqualContents(t, c.X, c.Y, trailRune, scn)
+		}
+	})
+
+	t.Run("apple kinds", func(t *testing.T) {
+		scn := setup(t)
+
+		for i, kind := range AppleKinds {
+			ar := AppleRenderer{Pos: Position{X: i, Y: 2}, Kind: kind}
+			ar.Draw(scn)
+
+			requireEqualContents(t, i, 2, appleRunes[kind], scn)
+			_, _, style, _ := scn.GetContent(i, 2)
+			if style != styles[appleStyles[kind]] {
+				t.Errorf("%s apple drawn with the wrong style", kind)
+			}
+		}
+	})
+
+	t.Run("apple kind text round trip", func(t *testing.T) {
+		for _, kind := range AppleKinds {
+			text, err := kind.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var act AppleKind
+			if err = act.UnmarshalText(text); err != nil || act != kind {
+				t.Errorf("expected %s, got %s (%v)", kind, act, err)
+			}
+		}
+		var k AppleKind
+		if k.UnmarshalText([]byte("rotten")) == nil {
+			t.Error("expected an error for an unknown kind")
+		}
+	})
+
 	t.Run("apple", func(t *testing.T) {
 		scn := setup(t)
 
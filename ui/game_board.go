@@ -55,6 +55,13 @@ func (b *GameBoardRenderer) Height() int {
 	return b.height
 }
 
+// SetSize changes the dimensions of the board, keeping its upper left corner in place.
+func (b *GameBoardRenderer) SetSize(width int, height int) {
+	b.width = width
+	b.height = height
+	b.hud.SetWidth(width - 2)
+}
+
 func (b *GameBoardRenderer) setHud(hud *Hud) {
 	if b.hud != nil {
 		_ = b.Remove(b.hud)
@@ -71,6 +78,29 @@ func (b *GameBoardRenderer) LivesBox() *TextBox {
 	return b.hud.LivesBox()
 }
 
+// SetMode shows the game mode in the HUD.
+func (b *GameBoardRenderer) SetMode(text string) {
+	b.hud.SetMode(text)
+}
+
+// SetEffects shows the active effects in the HUD.
+func (b *GameBoardRenderer) SetEffects(effects []EffectStatus) {
+	b.hud.SetEffects(effects)
+}
+
+func (b *GameBoardRenderer) ModeBox() *TextBox {
+	return b.hud.ModeBox()
+}
+
+// SetGoal shows the progress towards the goal of a level in the HUD.
+func (b *GameBoardRenderer) SetGoal(text string) {
+	b.hud.SetGoal(text)
+}
+
+func (b *GameBoardRenderer) GoalBox() *TextBox {
+	return b.hud.GoalBox()
+}
+
 func NewGameBoardRenderer(ul Position, width int, height int) *GameBoardRenderer {
 	ret := GameBoardRenderer{
 		ul:     ul,
@@ -63,6 +63,20 @@ func Test_Board(t *testing.T) {
 	})
 }
 
+func Test_BoardSetSize(t *testing.T) {
+	board := NewGameBoardRenderer(Position{X: 2, Y: 3}, 10, 10)
+
+	board.SetSize(20, 15)
+
+	require.Equal(t, 20, board.Width())
+	require.Equal(t, 15, board.Height())
+	require.Equal(t, 2, board.Left())
+	require.Equal(t, 21, board.Right())
+	require.Equal(t, 17, board.Bottom())
+	require.Equal(t, 18, board.hud.Width())
+	require.Equal(t, 18, board.ScoreBox().Width())
+}
+
 func requireEqualScreen(t *testing.T, exp [][]rune, act tcell.SimulationScreen) {
 	for y := range exp {
 		for x := range exp[y] {
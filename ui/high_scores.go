@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	HighScoresViewName = "HighScores"
+	highScoresTitle    = "High Scores"
+	noHighScoresText   = "No high scores yet"
+	// highScoreFormat and highScoreHeaderFormat line up, so the header sits above its column
+	highScoreFormat       = "%2d. %-10.10s %6d %4d %5s %-8.8s %s"
+	highScoreHeaderFormat = "%3s %-10s %6s %4s %5s %-8s %-10s"
+	highScoreDate         = "2006-01-02"
+)
+
+var highScoreHeader = fmt.Sprintf(highScoreHeaderFormat, "", "Name", "Score", "Len", "Time", "Mode", "Date")
+
+// HighScoreRow is an entry shown in a HighScoresView.
+type HighScoreRow struct {
+	Name     string
+	Score    uint
+	Length   int
+	Duration time.Duration
+	Mode     string
+	Date     time.Time
+}
+
+// String lays the row out in columns, after its rank in the table.
+func (r HighScoreRow) String(rank int) string {
+	d := r.Duration.Round(time.Second)
+	played := fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+	return fmt.Sprintf(highScoreFormat, rank, r.Name, r.Score, r.Length, played, r.Mode, r.Date.Format(highScoreDate))
+}
+
+// HighScoresView lists the high-score table, best first, with one row optionally
+// highlighted. The table is centered on the screen below the title.
+type HighScoresView struct {
+	composite
+	width, height int
+	title         *TextBox
+	header        *TextBox
+	lines         []*TextBox
+}
+
+// Draw clears the screen first so nothing from the previous view is left behind.
+func (v *HighScoresView) Draw(scrn tcell.Screen) {
+	fill(Position{X: 0, Y: 0}, v.width, v.height, boardStyle, scrn)
+	v.composite.Draw(scrn)
+}
+
+func (v *HighScoresView) Width() int {
+	return v.width
+}
+
+func (v *HighScoresView) Height() int {
+	return v.height
+}
+
+func (v *HighScoresView) Resize(width, height int) {
+	v.width = width
+	v.height = height
+
+	top := (height - len(v.lines) - 3) / 2
+	v.title.SetWidth(width).SetPosition(Position{X: 0, Y: top})
+	v.header.SetWidth(width).SetPosition(Position{X: 0, Y: top + 2})
+	for i, line := range v.lines {
+		line.SetWidth(width).SetPosition(Position{X: 0, Y: top + 3 + i})
+	}
+}
+
+// SetRows replaces the listed rows, highlighting the one at index highlight. Pass -1 to
+// highlight none.
+func (v *HighScoresView) SetRows(rows []HighScoreRow, highlight int) {
+	for _, line := range v.lines {
+		_ = v.Remove(line)
+	}
+	v.lines = make([]*TextBox, len(rows))
+	for i, r := range rows {
+		style := boardStyle
+		if i == highlight {
+			style = styles[selectedStyle]
+		}
+		v.lines[i] = NewTextBoxWithAlignment(r.String(i+1), CenterAlignment, style).NoBorder()
+		_ = v.Add(v.lines[i])
+	}
+	if len(rows) == 0 {
+		v.header.SetText(noHighScoresText)
+	} else {
+		v.header.SetText(highScoreHeader)
+	}
+	v.Resize(v.width, v.height)
+}
+
+func NewHighScoresView() *HighScoresView {
+	ret := HighScoresView{
+		title:  NewTextBoxWithAlignment(highScoresTitle, CenterAlignment, boardStyle).NoBorder(),
+		header: NewTextBoxWithAlignment(noHighScoresText, CenterAlignment, boardStyle).NoBorder(),
+	}
+	_ = ret.Add(ret.title)
+	_ = ret.Add(ret.header)
+	return &ret
+}
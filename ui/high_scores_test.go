@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HighScoresView(t *testing.T) {
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	rows := []HighScoreRow{
+		{Name: "Ann", Score: 1200, Length: 15, Duration: 95 * time.Second, Mode: "wrap", Date: date},
+		{Name: "Bob", Score: 300, Length: 6, Duration: 20 * time.Second, Mode: "solid", Date: date},
+	}
+	const width, height = 60, 12
+
+	t.Run("lays out each row in columns", func(t *testing.T) {
+		require.Equal(t, " 1. Ann          1200   15  1:35 wrap     2026-10-18", rows[0].String(1))
+		require.Len(t, highScoreHeader, len(rows[0].String(1)))
+	})
+
+	t.Run("lists the rows below the title and header", func(t *testing.T) {
+		scrn := setupScreen(t, width, height)
+		view := NewHighScoresView()
+		view.Resize(width, height)
+		view.SetRows(rows, -1)
+
+		view.Draw(scrn)
+
+		readLine := func(y int) string {
+			var b strings.Builder
+			for x := range width {
+				ch, _, _, _ := scrn.GetContent(x, y)
+				b.WriteRune(ch)
+			}
+			return strings.TrimSpace(b.String())
+		}
+		top := (height - len(rows) - 3) / 2
+		require.Equal(t, highScoresTitle, readLine(top))
+		require.Equal(t, strings.TrimSpace(highScoreHeader), readLine(top+2))
+		require.Equal(t, strings.TrimSpace(rows[0].String(1)), readLine(top+3))
+		require.Equal(t, strings.TrimSpace(rows[1].String(2)), readLine(top+4))
+	})
+
+	t.Run("highlights a row", func(t *testing.T) {
+		scrn := setupScreen(t, width, height)
+		view := NewHighScoresView()
+		view.Resize(width, height)
+		view.SetRows(rows, 1)
+
+		view.Draw(scrn)
+
+		top := (height - len(rows) - 3) / 2
+		_, _, style, _ := scrn.GetContent(width/2, top+4)
+		require.Equal(t, styles[selectedStyle], style)
+		_, _, style, _ = scrn.GetContent(width/2, top+3)
+		require.Equal(t, boardStyle, style)
+	})
+
+	t.Run("says when there are no scores", func(t *testing.T) {
+		view := NewHighScoresView()
+
+		view.SetRows(nil, -1)
+
+		require.Equal(t, noHighScoresText, view.header.text)
+	})
+}
@@ -20,12 +20,26 @@ var (
 // their score, remaining lives, etc.
 type Hud struct {
 	composite
-	pos    Position
-	height int
-	width  int
-	title  *TextBox
-	score  *TextBox
-	lives  *TextBox
+	pos     Position
+	height  int
+	width   int
+	title   *TextBox
+	score   *TextBox
+	lives   *TextBox
+	mode    *TextBox
+	goal    *TextBox
+	effects []EffectStatus
+}
+
+func (d *Hud) Draw(scrn tcell.Screen) {
+	d.composite.Draw(scrn)
+	for _, box := range []*TextBox{d.mode, d.goal} {
+		if box.text != "" {
+			box.Draw(scrn)
+		}
+	}
+	_, y := d.score.Position()
+	drawEffects(d.effects, Position{X: d.pos.X + d.width - 1, Y: y}, scrn)
 }
 
 func (d *Hud) SetPosition(pos Position) {
@@ -40,6 +54,49 @@ func (d *Hud) Width() int {
 	return d.width
 }
 
+// SetWidth changes the width of the Hud along with all of its boxes.
+func (d *Hud) SetWidth(width int) {
+	d.width = width
+	for _, box := range []*TextBox{d.title, d.score, d.lives} {
+		box.SetWidth(width)
+	}
+	d.layoutMode()
+	d.layoutGoal()
+}
+
+// SetMode shows the game mode at the right end of the lives line.
+func (d *Hud) SetMode(text string) {
+	d.mode.SetText(text)
+	d.layoutMode()
+}
+
+// SetGoal shows the progress towards the goal of a level at the right end of the title line.
+func (d *Hud) SetGoal(text string) {
+	d.goal.SetText(text)
+	d.layoutGoal()
+}
+
+// SetEffects shows the active effects with countdown bars at the right end of the score line.
+func (d *Hud) SetEffects(effects []EffectStatus) {
+	d.effects = effects
+}
+
+func (d *Hud) Effects() []EffectStatus {
+	return d.effects
+}
+
+func (d *Hud) layoutMode() {
+	_, y := d.lives.Position()
+	d.mode.SetWidth(len(d.mode.text)).
+		SetPosition(Position{X: d.pos.X + d.width - len(d.mode.text), Y: y})
+}
+
+func (d *Hud) layoutGoal() {
+	_, y := d.title.Position()
+	d.goal.SetWidth(len(d.goal.text)).
+		SetPosition(Position{X: d.pos.X + d.width - len(d.goal.text), Y: y})
+}
+
 func (d *Hud) Bottom() int {
 	return d.pos.Y + d.Height()
 }
@@ -80,6 +137,14 @@ func (d *Hud) LivesBox() *TextBox {
 	return d.lives
 }
 
+func (d *Hud) ModeBox() *TextBox {
+	return d.mode
+}
+
+func (d *Hud) GoalBox() *TextBox {
+	return d.goal
+}
+
 func NewHud(pos Position, height, width int) *Hud {
 	boxHeight := height / 3
 	titleBox := NewTextBoxWithAlignment(title, CenterAlignment, boardStyle).
@@ -101,6 +166,10 @@ func NewHud(pos Position, height, width int) *Hud {
 	ret.SetTitleBox(titleBox)
 	ret.SetScoreBox(scoreBox)
 	ret.SetLivesBox(livesBox)
+	ret.mode = NewTextBox("", boardStyle).NoBorder()
+	ret.layoutMode()
+	ret.goal = NewTextBox("", boardStyle).NoBorder()
+	ret.layoutGoal()
 
 	return &ret
 }
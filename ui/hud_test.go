@@ -20,4 +20,45 @@ func Test_Hud(t *testing.T) {
 	t.Run("bottom is offset from position", func(t *testing.T) {
 		require.Equal(t, 3, displayBox.Bottom())
 	})
+
+	t.Run("mode is shown at the end of the lives line", func(t *testing.T) {
+		scrn := setupScreen(t, width, 3)
+		hud := NewHud(pos, height, width)
+
+		hud.SetMode("wrap")
+		hud.Draw(scrn)
+
+		_, y := hud.LivesBox().Position()
+		for i, ch := range "wrap" {
+			requireEqualContents(t, width-4+i, y, ch, scrn)
+		}
+		requireEqualContents(t, 0, y, 'L', scrn)
+	})
+
+	t.Run("goal is shown at the end of the title line, apart from the mode", func(t *testing.T) {
+		scrn := setupScreen(t, width, 3)
+		hud := NewHud(pos, height, width)
+
+		hud.SetMode("wrap")
+		hud.SetGoal("1/5")
+		hud.Draw(scrn)
+
+		_, y := hud.TitleBox().Position()
+		for i, ch := range "1/5" {
+			requireEqualContents(t, width-3+i, y, ch, scrn)
+		}
+		_, y = hud.LivesBox().Position()
+		requireEqualContents(t, width-4, y, 'w', scrn)
+	})
+
+	t.Run("setting width resizes boxes", func(t *testing.T) {
+		hud := NewHud(pos, height, width)
+
+		hud.SetWidth(20)
+
+		require.Equal(t, 20, hud.Width())
+		require.Equal(t, 20, hud.TitleBox().Width())
+		require.Equal(t, 20, hud.ScoreBox().Width())
+		require.Equal(t, 20, hud.LivesBox().Width())
+	})
 }
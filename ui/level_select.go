@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	LevelSelectViewName  = "LevelSelect"
+	levelEntryFormat     = "%d. %s"
+	lockedEntryFormat    = levelEntryFormat + " (locked)"
+	completedEntryFormat = levelEntryFormat + " (done)"
+)
+
+// LevelSelectEntry is a level shown in a LevelSelectView.
+type LevelSelectEntry struct {
+	Name      string
+	Locked    bool
+	Completed bool
+}
+
+// LevelSelectView lists the levels of a campaign with one of them selected. The levels are
+// centered on the screen below the title.
+type LevelSelectView struct {
+	composite
+	width, height int
+	selected      int
+	title         *TextBox
+	entries       []LevelSelectEntry
+	lines         []*TextBox
+}
+
+// Draw clears the screen before drawing the list so nothing from the previous view is left
+// behind.
+func (v *LevelSelectView) Draw(scrn tcell.Screen) {
+	fill(Position{X: 0, Y: 0}, v.width, v.height, boardStyle, scrn)
+	v.composite.Draw(scrn)
+}
+
+func (v *LevelSelectView) Width() int {
+	return v.width
+}
+
+func (v *LevelSelectView) Height() int {
+	return v.height
+}
+
+func (v *LevelSelectView) Resize(width, height int) {
+	v.width = width
+	v.height = height
+
+	top := (height - len(v.lines) - 2) / 2
+	v.title.SetWidth(width).SetPosition(Position{X: 0, Y: top})
+	for i, line := range v.lines {
+		line.SetWidth(width).SetPosition(Position{X: 0, Y: top + 2 + i})
+	}
+}
+
+// SetEntries replaces the listed levels, keeping the selection when it's still in range.
+func (v *LevelSelectView) SetEntries(entries []LevelSelectEntry) {
+	for _, line := range v.lines {
+		_ = v.Remove(line)
+	}
+	v.entries = entries
+	v.lines = make([]*TextBox, len(entries))
+	for i, e := range entries {
+		format := levelEntryFormat
+		if e.Locked {
+			format = lockedEntryFormat
+		} else if e.Completed {
+			format = completedEntryFormat
+		}
+		v.lines[i] = NewTextBoxWithAlignment(fmt.Sprintf(format, i+1, e.Name), CenterAlignment, boardStyle).NoBorder()
+		_ = v.Add(v.lines[i])
+	}
+	v.Select(v.selected)
+	v.Resize(v.width, v.height)
+}
+
+// Select highlights the entry at index i, which is clamped to the listed levels.
+func (v *LevelSelectView) Select(i int) {
+	v.selected = max(min(i, len(v.entries)-1), 0)
+	for j, line := range v.lines {
+		switch {
+		case j == v.selected:
+			line.SetStyle(styles[selectedStyle])
+		case v.entries[j].Locked:
+			line.SetStyle(styles[lockedStyle])
+		default:
+			line.SetStyle(boardStyle)
+		}
+	}
+}
+
+func (v *LevelSelectView) Selected() int {
+	return v.selected
+}
+
+// SelectedEntry returns the highlighted entry, or false when there are no entries.
+func (v *LevelSelectView) SelectedEntry() (LevelSelectEntry, bool) {
+	if len(v.entries) == 0 {
+		return LevelSelectEntry{}, false
+	}
+	return v.entries[v.selected], true
+}
+
+func NewLevelSelectView(title string) *LevelSelectView {
+	ret := LevelSelectView{
+		title: NewTextBoxWithAlignment(title, CenterAlignment, boardStyle).NoBorder(),
+	}
+	_ = ret.Add(ret.title)
+	return &ret
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LevelSelectView(t *testing.T) {
+	entries := []LevelSelectEntry{
+		{Name: "Box", Completed: true},
+		{Name: "Cross"},
+		{Name: "Ring", Locked: true},
+	}
+	newView := func() *LevelSelectView {
+		view := NewLevelSelectView("Campaign")
+		view.SetEntries(entries)
+		view.Resize(30, 10)
+		return view
+	}
+
+	t.Run("lists levels below the title", func(t *testing.T) {
+		scrn := setupScreen(t, 30, 10)
+		view := newView()
+
+		view.Draw(scrn)
+
+		requireLine := func(y int, text string) {
+			x := (30 - len(text)) / 2
+			for i, ch := range text {
+				requireEqualContents(t, x+i, y, ch, scrn)
+			}
+		}
+		requireLine(2, "Campaign")
+		requireLine(4, "1. Box (done)")
+		requireLine(5, "2. Cross")
+		requireLine(6, "3. Ring (locked)")
+	})
+
+	t.Run("highlights the selected level", func(t *testing.T) {
+		scrn := setupScreen(t, 30, 10)
+		view := newView()
+
+		view.Select(1)
+		view.Draw(scrn)
+
+		_, _, style, _ := scrn.GetContent(11, 5)
+		require.Equal(t, styles[selectedStyle], style)
+		_, _, style, _ = scrn.GetContent(11, 4)
+		require.Equal(t, boardStyle, style)
+		_, _, style, _ = scrn.GetContent(11, 6)
+		require.Equal(t, styles[lockedStyle], style)
+	})
+
+	t.Run("selection is clamped to the levels", func(t *testing.T) {
+		view := newView()
+
+		view.Select(-1)
+		require.Equal(t, 0, view.Selected())
+
+		view.Select(5)
+		require.Equal(t, 2, view.Selected())
+		entry, ok := view.SelectedEntry()
+		require.True(t, ok)
+		require.Equal(t, entries[2], entry)
+	})
+
+	t.Run("replacing entries keeps the selection in range", func(t *testing.T) {
+		view := newView()
+		view.Select(2)
+
+		view.SetEntries(entries[:1])
+
+		require.Equal(t, 0, view.Selected())
+	})
+
+	t.Run("no entries", func(t *testing.T) {
+		view := NewLevelSelectView("Campaign")
+
+		_, ok := view.SelectedEntry()
+
+		require.False(t, ok)
+	})
+}
@@ -24,10 +24,20 @@ type Manager struct {
 	activeName       string
 	active           View
 	keyEventCallback func(*tcell.EventKey)
+	resizeCallback   func(width, height int)
 	modal            struct {
 		isActive bool
 		text     string
 	}
+	screen struct {
+		known         bool
+		width, height int
+	}
+	minimumSize struct {
+		width, height int
+		view          *TooSmallView
+		resumeName    string
+	}
 }
 
 func NewManager() *Manager {
@@ -49,7 +59,8 @@ func (m *Manager) AddView(name string, v View) {
 	}
 }
 
-// SwitchView makes the named view active.
+// SwitchView makes the named view active. While the screen is too small, the view is
+// only shown once the screen is large enough again.
 func (m *Manager) SwitchView(name string) error {
 	if m.views == nil {
 		return ErrNoViews
@@ -58,11 +69,53 @@ func (m *Manager) SwitchView(name string) error {
 	if !ok {
 		return fmt.Errorf("%w: %q", ErrViewNotFound, name)
 	}
+	if m.TooSmall() && name != TooSmallViewName {
+		m.minimumSize.resumeName = name
+		return nil
+	}
 	m.activeName = name
 	m.active = v
 	return nil
 }
 
+// SetMinimumSize registers the smallest screen the views can be drawn on. While the
+// screen is smaller, a TooSmallView is shown in place of the active view.
+func (m *Manager) SetMinimumSize(width, height int) {
+	if m.views == nil {
+		m.views = make(map[string]View)
+	}
+	m.minimumSize.width = width
+	m.minimumSize.height = height
+	m.minimumSize.view = NewTooSmallView(width, height)
+	m.minimumSize.view.Resize(m.screen.width, m.screen.height)
+	m.views[TooSmallViewName] = m.minimumSize.view
+	m.guardMinimumSize()
+}
+
+// TooSmall reports whether the screen is smaller than the minimum size. A screen with
+// an unknown size is never too small.
+func (m *Manager) TooSmall() bool {
+	return m.minimumSize.view != nil && m.screen.known &&
+		(m.screen.width < m.minimumSize.width || m.screen.height < m.minimumSize.height)
+}
+
+// guardMinimumSize swaps the active view for the TooSmallView when the screen is too small,
+// and back again once it's large enough.
+func (m *Manager) guardMinimumSize() {
+	if m.active == nil {
+		return
+	}
+	tooSmall := m.TooSmall()
+	switch {
+	case tooSmall && m.activeName != TooSmallViewName:
+		m.minimumSize.resumeName = m.activeName
+		m.activeName = TooSmallViewName
+		m.active = m.minimumSize.view
+	case !tooSmall && m.activeName == TooSmallViewName:
+		_ = m.SwitchView(m.minimumSize.resumeName)
+	}
+}
+
 func (m *Manager) ActiveViewName() string {
 	return m.activeName
 }
@@ -76,7 +129,17 @@ func (m *Manager) SetKeyEventCallback(callback func(*tcell.EventKey)) {
 	m.keyEventCallback = callback
 }
 
+// SetResizeEventCallback registers an optional handler that runs before the views are resized.
+func (m *Manager) SetResizeEventCallback(callback func(width, height int)) {
+	m.resizeCallback = callback
+}
+
 func (m *Manager) Handle(ev tcell.Event) {
+	if resizeEv, ok := ev.(*tcell.EventResize); ok {
+		m.resize(resizeEv.Size())
+		return
+	}
+
 	if m.active == nil {
 		return
 	}
@@ -90,6 +153,23 @@ func (m *Manager) Handle(ev tcell.Event) {
 	m.active.Handle(ev)
 }
 
+// resize notifies every registered view of the new screen size, not only the active one, so
+// switching views never shows a stale layout.
+func (m *Manager) resize(width, height int) {
+	m.screen.known = true
+	m.screen.width = width
+	m.screen.height = height
+	if m.resizeCallback != nil {
+		m.resizeCallback(width, height)
+	}
+	for _, v := range m.views {
+		if r, ok := v.(Resizable); ok {
+			r.Resize(width, height)
+		}
+	}
+	m.guardMinimumSize()
+}
+
 func (m *Manager) Draw(scrn tcell.Screen) {
 	if m.active == nil {
 		return
@@ -97,7 +177,7 @@ func (m *Manager) Draw(scrn tcell.Screen) {
 
 	m.active.Draw(scrn)
 
-	if m.modal.isActive {
+	if m.modal.isActive && m.activeName != TooSmallViewName {
 		ShowMessage(m.active, m.modal.text, scrn)
 	}
 }
@@ -138,6 +138,103 @@ func Test_Manager(t *testing.T) {
 		require.False(t, mgr.ModalVisible())
 	})
 
+	t.Run("resize events are propagated to every resizable view", func(t *testing.T) {
+		mgr := NewManager()
+		first, second := resizableMockView{}, resizableMockView{}
+		mgr.AddView("FirstView", &first)
+		mgr.AddView("SecondView", &second)
+
+		mgr.Handle(tcell.NewEventResize(30, 20))
+
+		require.Equal(t, [2]int{30, 20}, first.size)
+		require.Equal(t, [2]int{30, 20}, second.size)
+	})
+
+	t.Run("resize callback runs before views are resized", func(t *testing.T) {
+		mgr := NewManager()
+		view := resizableMockView{}
+		mgr.AddView("TestView", &view)
+		var sizeSeenByCallback [2]int
+		mgr.SetResizeEventCallback(func(width, height int) {
+			sizeSeenByCallback = view.size
+		})
+
+		mgr.Handle(tcell.NewEventResize(30, 20))
+
+		require.Equal(t, [2]int{}, sizeSeenByCallback)
+		require.Equal(t, [2]int{30, 20}, view.size)
+	})
+
+	t.Run("resize events are not sent to the key callback", func(t *testing.T) {
+		mgr := NewManager()
+		handler, wasRun := newKeyHandler()
+		mgr.AddView("TestView", &MockView{})
+		mgr.SetKeyEventCallback(handler)
+
+		mgr.Handle(tcell.NewEventResize(30, 20))
+
+		require.False(t, *wasRun)
+	})
+
+	t.Run("minimum size", func(t *testing.T) {
+		setupMinimumSize := func() *Manager {
+			mgr := NewManager()
+			mgr.AddView("FirstView", &MockView{})
+			mgr.AddView("SecondView", &MockView{})
+			mgr.SetMinimumSize(20, 10)
+			return mgr
+		}
+
+		t.Run("screen of unknown size isn't too small", func(t *testing.T) {
+			mgr := setupMinimumSize()
+
+			require.False(t, mgr.TooSmall())
+			require.Equal(t, "FirstView", mgr.ActiveViewName())
+		})
+
+		t.Run("too small view is shown when screen shrinks", func(t *testing.T) {
+			mgr := setupMinimumSize()
+
+			mgr.Handle(tcell.NewEventResize(19, 10))
+
+			require.True(t, mgr.TooSmall())
+			require.Equal(t, TooSmallViewName, mgr.ActiveViewName())
+		})
+
+		t.Run("previous view is shown when screen is large enough again", func(t *testing.T) {
+			mgr := setupMinimumSize()
+
+			mgr.Handle(tcell.NewEventResize(20, 9))
+			mgr.Handle(tcell.NewEventResize(20, 10))
+
+			require.False(t, mgr.TooSmall())
+			require.Equal(t, "FirstView", mgr.ActiveViewName())
+		})
+
+		t.Run("switching views while too small is deferred", func(t *testing.T) {
+			mgr := setupMinimumSize()
+			mgr.Handle(tcell.NewEventResize(5, 5))
+
+			require.NoError(t, mgr.SwitchView("SecondView"))
+			require.Equal(t, TooSmallViewName, mgr.ActiveViewName())
+
+			mgr.Handle(tcell.NewEventResize(50, 50))
+			require.Equal(t, "SecondView", mgr.ActiveViewName())
+		})
+
+		t.Run("modal isn't drawn while too small", func(t *testing.T) {
+			mgr := setupMinimumSize()
+			scrn := setupScreen(t, 5, 5)
+			mgr.Handle(tcell.NewEventResize(5, 5))
+			mgr.ShowModal("M")
+
+			mgr.Draw(scrn)
+
+			act, _, _, _ := scrn.GetContent(1, 1)
+			require.NotEqual(t, tcell.RuneULCorner, act, "modal border was drawn")
+		})
+	})
+
 	t.Run("height and width report 0 when no views are active", func(t *testing.T) {
 		mgr := NewManager()
 
@@ -168,6 +265,15 @@ func (m *MockView) Handle(e tcell.Event) {
 	m.e = e
 }
 
+type resizableMockView struct {
+	MockView
+	size [2]int
+}
+
+func (r *resizableMockView) Resize(width, height int) {
+	r.size = [2]int{width, height}
+}
+
 func (m *MockView) assertWasNotified(t *testing.T) {
 	require.NotNil(t, m.e)
 }
@@ -1,19 +1,103 @@
 package ui
 
 import (
+	"fmt"
+
 	"github.com/gdamore/tcell/v2"
 )
 
+// MenuItem is an entry of a Menu that can be selected, which either runs its action, opens
+// its submenu or steps its value when activated.
+type MenuItem struct {
+	menu     *Menu
+	box      *TextBox
+	text     string
+	action   func()
+	submenu  *Menu
+	value    MenuValue
+	label    bool
+	disabled bool
+}
+
+// Text returns the text of the item, followed by its value if it has one.
+func (i *MenuItem) Text() string {
+	if i.value != nil {
+		return fmt.Sprintf(menuValueFormat, i.text, i.value)
+	}
+	return i.text
+}
+
+func (i *MenuItem) SetText(text string) *MenuItem {
+	i.text = text
+	i.box.SetText(text)
+	return i
+}
+
+func (i *MenuItem) Disabled() bool {
+	return i.disabled
+}
+
+// SetDisabled greys the item out so it can't be selected. When the selected item is disabled
+// the selection moves on to the next item that can be.
+func (i *MenuItem) SetDisabled(disabled bool) *MenuItem {
+	i.disabled = disabled
+	if m := i.menu; m.selected < 0 || !m.items[m.selected].selectable() {
+		m.selected = m.next(m.selected, 1)
+	}
+	i.menu.restyle()
+	return i
+}
+
+// Submenu returns the menu the item opens, or nil if it runs an action instead.
+func (i *MenuItem) Submenu() *Menu {
+	return i.submenu
+}
+
+// SetAction makes the item call action when it's activated, in place of stepping its value.
+func (i *MenuItem) SetAction(action func()) *MenuItem {
+	i.action = action
+	return i
+}
+
+// Value returns the value the item changes, or nil if it doesn't have one.
+func (i *MenuItem) Value() MenuValue {
+	return i.value
+}
+
+func (i *MenuItem) selectable() bool {
+	return !i.label && !i.disabled
+}
+
+// Menu lists entries below a title inside a border. Items can be selected with the arrow keys
+// and activated with Enter, which runs their action or opens their submenu in place of the
+// menu. Items with a value are stepped with Left and Right instead. Escape, or Left on an item
+// without a value, goes back out of a submenu.
 type Menu struct {
 	composite
-	ul      Position
-	width   int
-	height  int
-	title   *TextBox
-	entries []*TextBox
+	ul       Position
+	width    int
+	height   int
+	title    *TextBox
+	entries  []*TextBox
+	items    []*MenuItem
+	selected int
+	// open is the submenu being shown in place of this menu, if any.
+	open *Menu
 }
 
 func (m *Menu) Draw(scn tcell.Screen) {
+	if m.open != nil {
+		m.open.Draw(scn)
+		return
+	}
+	for _, item := range m.items {
+		// values may have changed since the menu was last drawn, and the menu may have shrunk
+		if text := []rune(item.Text()); len(text) > m.contentWidth() {
+			item.box.SetText(string(text[:max(m.contentWidth(), 0)]))
+		} else {
+			item.box.SetText(string(text))
+		}
+	}
 	fill(m.ul, m.Width(), m.Height(), boardStyle, scn)
 	drawBorder(m.ul, m.Width(), m.Height(), boardStyle, scn)
 	m.composite.Draw(scn)
@@ -31,18 +115,78 @@ func (m *Menu) Height() int {
 	return min(ret, m.height)
 }
 
+// SetPosition moves the menu along with its title, entries and submenus.
+func (m *Menu) SetPosition(ul Position) {
+	dx, dy := ul.X-m.ul.X, ul.Y-m.ul.Y
+	m.ul = ul
+	for _, box := range append([]*TextBox{m.title}, m.entries...) {
+		x, y := box.Position()
+		box.SetPosition(Position{X: x + dx, Y: y + dy})
+	}
+	for _, item := range m.items {
+		if item.submenu != nil {
+			item.submenu.SetPosition(ul)
+		}
+	}
+}
+
+// SetSize changes the dimensions of the menu, resizing the title, entries and submenus to fit.
+func (m *Menu) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	for _, box := range append([]*TextBox{m.title}, m.entries...) {
+		box.SetWidth(m.contentWidth())
+	}
+	for _, item := range m.items {
+		if item.submenu != nil {
+			item.submenu.SetSize(width, height)
+		}
+	}
+}
+
 func (m *Menu) contentWidth() int {
 	return m.Width() - borderWidth*2
 }
 
+// AddEntry adds a line of text that can't be selected.
 func (m *Menu) AddEntry(text string) {
+	m.addItem(&MenuItem{label: true}, text)
+}
+
+// AddItem adds an entry that calls action when it's activated.
+func (m *Menu) AddItem(text string, action func()) *MenuItem {
+	return m.addItem(&MenuItem{action: action}, text)
+}
+
+// AddSubmenu adds an entry that opens submenu in place of this menu when it's activated. The
+// submenu is moved and sized to match this menu.
+func (m *Menu) AddSubmenu(text string, submenu *Menu) *MenuItem {
+	submenu.SetPosition(m.ul)
+	submenu.SetSize(m.width, m.height)
+	return m.addItem(&MenuItem{submenu: submenu}, text)
+}
+
+// AddValue adds an entry showing a value after its text, which is stepped in place.
+func (m *Menu) AddValue(text string, value MenuValue) *MenuItem {
+	return m.addItem(&MenuItem{value: value}, text)
+}
+
+func (m *Menu) addItem(item *MenuItem, text string) *MenuItem {
 	pos := m.calculatePosOfNextEntry()
-	entryBox := NewTextBoxWithAlignment(text, CenterAlignment, boardStyle).
+	item.menu = m
+	item.text = text
+	item.box = NewTextBoxWithAlignment(item.Text(), CenterAlignment, boardStyle).
 		SetPosition(pos).
 		SetWidth(m.contentWidth()).
 		NoBorder()
-	_ = m.Add(entryBox)
-	m.entries = append(m.entries, entryBox)
+	_ = m.Add(item.box)
+	m.entries = append(m.entries, item.box)
+	m.items = append(m.items, item)
+	if m.selected < 0 && item.selectable() {
+		m.selected = len(m.items) - 1
+	}
+	m.restyle()
+	return item
 }
 
 func (m *Menu) calculatePosOfNextEntry() Position {
@@ -58,14 +202,146 @@ func (m *Menu) calculatePosOfNextEntry() Position {
 	return pos
 }
 
+// Select highlights the entry at index i, unless it can't be selected.
+func (m *Menu) Select(i int) {
+	if i >= 0 && i < len(m.items) && m.items[i].selectable() {
+		m.selected = i
+		m.restyle()
+	}
+}
+
+// Selected returns the index of the highlighted entry, or -1 when no entry can be selected.
+func (m *Menu) Selected() int {
+	return m.selected
+}
+
+// SelectedItem returns the highlighted item, or nil when no entry can be selected.
+func (m *Menu) SelectedItem() *MenuItem {
+	if m.selected < 0 {
+		return nil
+	}
+	return m.items[m.selected]
+}
+
+// SelectNext moves the highlight of the open menu down to the next item that can be
+// selected, wrapping around to the top.
+func (m *Menu) SelectNext() {
+	c := m.current()
+	c.Select(c.next(c.selected, 1))
+}
+
+// SelectPrevious moves the highlight of the open menu up to the previous item that can be
+// selected, wrapping around to the bottom.
+func (m *Menu) SelectPrevious() {
+	c := m.current()
+	c.Select(c.next(c.selected, -1))
+}
+
+// Activate runs the action of the item highlighted in the open menu, opens its submenu or
+// steps its value forwards when it has no action.
+func (m *Menu) Activate() {
+	c := m.current()
+	item := c.SelectedItem()
+	switch {
+	case item == nil:
+	case item.submenu != nil:
+		c.open = item.submenu
+	case item.action != nil:
+		item.action()
+	case item.value != nil:
+		m.Adjust(1)
+	}
+}
+
+// Adjust steps the value of the item highlighted in the open menu, reporting whether it has
+// a value to step.
+func (m *Menu) Adjust(dir int) bool {
+	item := m.current().SelectedItem()
+	if item == nil || item.value == nil {
+		return false
+	}
+	item.value.Step(dir)
+	return true
+}
+
+// Back closes the innermost open submenu, reporting whether there was one to close.
+func (m *Menu) Back() bool {
+	if m.open == nil {
+		return false
+	}
+	if !m.open.Back() {
+		m.open = nil
+	}
+	return true
+}
+
+// current returns the innermost open submenu, or the menu itself when none is open.
+func (m *Menu) current() *Menu {
+	ret := m
+	for ret.open != nil {
+		ret = ret.open
+	}
+	return ret
+}
+
+// next returns the index of the first item after from, in the direction step, that can be
+// selected, or -1 if there's none.
+func (m *Menu) next(from, step int) int {
+	n := len(m.items)
+	if from < 0 && step < 0 {
+		from = 0
+	}
+	for i := 1; i <= n; i++ {
+		j := ((from+step*i)%n + n) % n
+		if m.items[j].selectable() {
+			return j
+		}
+	}
+	return -1
+}
+
+func (m *Menu) restyle() {
+	for i, item := range m.items {
+		switch {
+		case i == m.selected:
+			item.box.SetStyle(styles[selectedStyle])
+		case item.disabled:
+			item.box.SetStyle(styles[lockedStyle])
+		default:
+			item.box.SetStyle(boardStyle)
+		}
+	}
+}
+
+func (m *Menu) handleKeyEvent(ev *tcell.EventKey) {
+	m.composite.handleKeyEvent(ev)
+	switch ev.Key() {
+	case tcell.KeyUp:
+		m.SelectPrevious()
+	case tcell.KeyDown:
+		m.SelectNext()
+	case tcell.KeyEnter:
+		m.Activate()
+	case tcell.KeyLeft:
+		if !m.Adjust(-1) {
+			m.Back()
+		}
+	case tcell.KeyRight:
+		m.Adjust(1)
+	case tcell.KeyEscape:
+		m.Back()
+	}
+}
+
 func NewMenu(ul Position, width, height int, title string) *Menu {
-	maxEntries := height - 1
+	maxEntries := max(height-1, 0)
 	ret := Menu{
 		composite: composite{},
 		ul:        ul,
 		width:     width,
 		height:    height,
 		entries:   make([]*TextBox, 0, maxEntries),
+		selected:  -1,
 	}
 
 	titleBox := NewTextBoxWithAlignment(title, CenterAlignment, boardStyle).
@@ -3,6 +3,7 @@ package ui
 import (
 	"testing"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,4 +55,179 @@ func Test_Menu(t *testing.T) {
 			require.Equal(t, exp, entry.Width())
 		}
 	})
+
+	t.Run("moving menu moves title and entries", func(t *testing.T) {
+		menu := setup()
+		menu.AddEntry("Entry 1")
+		_, titleY := menu.title.Position()
+		_, entryY := menu.entries[0].Position()
+
+		menu.SetPosition(Position{X: 3, Y: 4})
+
+		x, y := menu.title.Position()
+		require.Equal(t, Position{X: 3 + borderWidth, Y: titleY + 4}, Position{X: x, Y: y})
+		x, y = menu.entries[0].Position()
+		require.Equal(t, Position{X: 3 + borderWidth, Y: entryY + 4}, Position{X: x, Y: y})
+	})
+
+	t.Run("resizing menu resizes title and entries", func(t *testing.T) {
+		menu := setup()
+		menu.AddEntry("Entry 1")
+
+		menu.SetSize(20, 10)
+
+		require.Equal(t, 20, menu.Width())
+		require.Equal(t, 20-borderWidth*2, menu.title.Width())
+		require.Equal(t, 20-borderWidth*2, menu.entries[0].Width())
+	})
+
+	t.Run("first enabled item is selected", func(t *testing.T) {
+		menu := setup()
+		menu.AddEntry("Label")
+		menu.AddItem("Off", nil).SetDisabled(true)
+		menu.AddItem("On", nil)
+
+		require.Equal(t, 2, menu.Selected())
+		require.Equal(t, "On", menu.SelectedItem().Text())
+	})
+
+	t.Run("a menu without items has nothing selected", func(t *testing.T) {
+		menu := setup()
+		menu.AddEntry("Label")
+
+		menu.SelectNext()
+
+		require.Equal(t, -1, menu.Selected())
+		require.Nil(t, menu.SelectedItem())
+	})
+
+	t.Run("arrow keys skip labels and disabled items and wrap around", func(t *testing.T) {
+		menu := setup()
+		menu.AddItem("One", nil)
+		menu.AddEntry("Label")
+		menu.AddItem("Two", nil).SetDisabled(true)
+		menu.AddItem("Three", nil)
+
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+		require.Equal(t, 3, menu.Selected())
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+		require.Equal(t, 0, menu.Selected())
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone))
+		require.Equal(t, 3, menu.Selected())
+	})
+
+	t.Run("disabling the selected item moves the selection on", func(t *testing.T) {
+		menu := setup()
+		one := menu.AddItem("One", nil)
+		menu.AddItem("Two", nil)
+
+		one.SetDisabled(true)
+
+		require.Equal(t, 1, menu.Selected())
+		require.True(t, one.Disabled())
+	})
+
+	t.Run("entries are styled by whether they're selected or disabled", func(t *testing.T) {
+		menu := setup()
+		menu.AddItem("One", nil)
+		menu.AddItem("Two", nil).SetDisabled(true)
+		menu.AddItem("Three", nil)
+
+		require.Equal(t, styles[selectedStyle], menu.entries[0].style)
+		require.Equal(t, styles[lockedStyle], menu.entries[1].style)
+		require.Equal(t, boardStyle, menu.entries[2].style)
+	})
+
+	t.Run("enter runs the selected item's action", func(t *testing.T) {
+		menu := setup()
+		var ran []string
+		menu.AddItem("One", func() { ran = append(ran, "One") })
+		menu.AddItem("Two", func() { ran = append(ran, "Two") })
+
+		menu.SelectNext()
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+
+		require.Equal(t, []string{"Two"}, ran)
+	})
+
+	t.Run("submenus open in place and escape goes back", func(t *testing.T) {
+		menu := setup()
+		ran := false
+		sub := NewMenu(ul, 5, 5, "Sub")
+		sub.AddItem("Inner", func() { ran = true })
+		menu.AddSubmenu("Open", sub)
+
+		menu.Activate()
+		require.Equal(t, sub, menu.current())
+		menu.Activate()
+		require.True(t, ran)
+
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+		require.Equal(t, menu, menu.current())
+		require.False(t, menu.Back())
+	})
+
+	t.Run("submenus are drawn in place of the menu", func(t *testing.T) {
+		scrn := setupScreen(t, 10, 10)
+		menu := setup()
+		sub := NewMenu(ul, 5, 5, "Sub")
+		menu.AddSubmenu("Open", sub)
+
+		menu.Activate()
+		menu.Draw(scrn)
+
+		require.Equal(t, 10, sub.Width())
+		requireEqualContents(t, 3, 1, 'S', scrn)
+		requireEqualContents(t, 4, 1, 'u', scrn)
+	})
+
+	t.Run("left and right step values, and enter steps forwards", func(t *testing.T) {
+		menu := setup()
+		lives := NewStepper(1, 9, 1).SetValue(3)
+		item := menu.AddValue("Lives", lives)
+
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+		require.Equal(t, 5, lives.Value())
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+		require.Equal(t, 4, lives.Value())
+		require.Equal(t, "Lives: 4", item.Text())
+	})
+
+	t.Run("enter runs the action of a value instead of stepping it", func(t *testing.T) {
+		menu := setup()
+		lives := NewStepper(1, 9, 1).SetValue(3)
+		ran := false
+		menu.AddValue("Lives", lives).SetAction(func() { ran = true })
+
+		menu.Activate()
+		require.True(t, ran)
+		require.Equal(t, 3, lives.Value())
+
+		require.True(t, menu.Adjust(1))
+		require.Equal(t, 4, lives.Value())
+	})
+
+	t.Run("left backs out of a submenu from an item without a value", func(t *testing.T) {
+		menu := setup()
+		sub := NewMenu(ul, 5, 5, "Sub")
+		sub.AddItem("Inner", nil)
+		menu.AddSubmenu("Open", sub)
+		menu.Activate()
+
+		menu.handleKeyEvent(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+
+		require.Equal(t, menu, menu.current())
+		require.False(t, menu.Adjust(1))
+	})
+
+	t.Run("entries are cut to fit the menu", func(t *testing.T) {
+		scrn := setupScreen(t, 10, 10)
+		menu := setup()
+		menu.AddValue("Long", NewChoice("far-from-head"))
+
+		menu.Draw(scrn)
+
+		require.Equal(t, "Long: fa", menu.entries[0].text)
+	})
 }
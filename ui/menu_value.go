@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"strconv"
+)
+
+const (
+	menuValueFormat = "%s: %s"
+	toggleOnText    = "on"
+	toggleOffText   = "off"
+)
+
+// MenuValue is a value shown after the label of a menu item, which is changed in place with
+// Left and Right.
+type MenuValue interface {
+	String() string
+	// Step moves the value on by one step, forwards when dir is positive and back otherwise.
+	Step(dir int)
+}
+
+// Stepper is a whole number kept between a minimum and a maximum.
+type Stepper struct {
+	value, min, max, step int
+	format                func(int) string
+}
+
+func (s *Stepper) String() string {
+	if s.format != nil {
+		return s.format(s.value)
+	}
+	return strconv.Itoa(s.value)
+}
+
+// Step adds or takes away one step, stopping at either end of the range. A value set
+// outside the range is brought back into it.
+func (s *Stepper) Step(dir int) {
+	value := s.value - s.step
+	if dir > 0 {
+		value = s.value + s.step
+	}
+	s.value = max(min(value, s.max), s.min)
+}
+
+func (s *Stepper) Value() int {
+	return s.value
+}
+
+// SetValue changes the value. It's kept as it is, even outside the range of the stepper,
+// until it's stepped.
+func (s *Stepper) SetValue(value int) *Stepper {
+	s.value = value
+	return s
+}
+
+// SetFormat changes how the value is shown, for values that mean something special.
+func (s *Stepper) SetFormat(format func(int) string) *Stepper {
+	s.format = format
+	return s
+}
+
+// NewStepper returns a stepper between lo and hi, inclusive, which starts at lo.
+func NewStepper(lo, hi, step int) *Stepper {
+	return &Stepper{value: lo, min: lo, max: hi, step: step}
+}
+
+// Toggle is a setting that's either on or off.
+type Toggle struct {
+	on bool
+}
+
+func (t *Toggle) String() string {
+	if t.on {
+		return toggleOnText
+	}
+	return toggleOffText
+}
+
+// Step turns the toggle over, whichever way it's stepped.
+func (t *Toggle) Step(int) {
+	t.on = !t.on
+}
+
+func (t *Toggle) Value() bool {
+	return t.on
+}
+
+func (t *Toggle) SetValue(on bool) *Toggle {
+	t.on = on
+	return t
+}
+
+func NewToggle() *Toggle {
+	return &Toggle{}
+}
+
+// Choice is one of a list of options, which stepping cycles through.
+type Choice struct {
+	options  []string
+	selected int
+}
+
+func (c *Choice) String() string {
+	return c.options[c.selected]
+}
+
+// Step moves to the next or previous option, wrapping around at either end.
+func (c *Choice) Step(dir int) {
+	if dir > 0 {
+		c.Select(c.selected + 1)
+	} else {
+		c.Select(c.selected - 1)
+	}
+}
+
+func (c *Choice) Selected() int {
+	return c.selected
+}
+
+// Select picks the option at index i, wrapping around the list of options.
+func (c *Choice) Select(i int) *Choice {
+	n := len(c.options)
+	c.selected = (i%n + n) % n
+	return c
+}
+
+// NewChoice returns a choice between the given options, with the first one picked.
+func NewChoice(options ...string) *Choice {
+	return &Choice{options: options}
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Stepper(t *testing.T) {
+	t.Run("steps within its range", func(t *testing.T) {
+		s := NewStepper(1, 10, 4)
+
+		s.Step(1)
+		require.Equal(t, 5, s.Value())
+		s.Step(1)
+		s.Step(1)
+		require.Equal(t, 10, s.Value())
+		s.Step(-1)
+		require.Equal(t, 6, s.Value())
+	})
+
+	t.Run("keeps values set outside its range until stepped", func(t *testing.T) {
+		s := NewStepper(1, 10, 1)
+
+		require.Equal(t, 50, s.SetValue(50).Value())
+		require.Equal(t, "50", s.String())
+		s.Step(-1)
+		require.Equal(t, 10, s.Value())
+
+		s.SetValue(-5).Step(-1)
+		require.Equal(t, 1, s.Value())
+	})
+
+	t.Run("shows the value with its format", func(t *testing.T) {
+		s := NewStepper(0, 10, 1).SetValue(3)
+		require.Equal(t, "3", s.String())
+
+		s.SetFormat(func(v int) string { return fmt.Sprintf("%d lives", v) })
+		require.Equal(t, "3 lives", s.String())
+	})
+}
+
+func Test_Toggle(t *testing.T) {
+	t.Run("flips either way", func(t *testing.T) {
+		tg := NewToggle()
+		require.Equal(t, "off", tg.String())
+
+		tg.Step(1)
+		require.True(t, tg.Value())
+		require.Equal(t, "on", tg.String())
+		tg.Step(-1)
+		require.False(t, tg.Value())
+	})
+}
+
+func Test_Choice(t *testing.T) {
+	t.Run("cycles through its options", func(t *testing.T) {
+		c := NewChoice("a", "b", "c")
+		require.Equal(t, "a", c.String())
+
+		c.Step(-1)
+		require.Equal(t, "c", c.String())
+		c.Step(1)
+		c.Step(1)
+		require.Equal(t, 1, c.Selected())
+	})
+
+	t.Run("wraps selections outside the options", func(t *testing.T) {
+		require.Equal(t, 0, NewChoice("a", "b").Select(4).Selected())
+	})
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	NameEntryViewName = "NameEntry"
+	nameEntryPrompt   = "Enter your name:"
+)
+
+// NameEntryView asks the player for their name, with a title above the prompt and the
+// field to type it into below. Everything is centered on the screen. The prompt can be
+// changed to ask for something else that's typed on one line.
+type NameEntryView struct {
+	composite
+	width, height int
+	title         *TextBox
+	prompt        *TextBox
+	field         *TextField
+}
+
+// Draw clears the screen first so nothing from the previous view is left behind.
+func (v *NameEntryView) Draw(scrn tcell.Screen) {
+	fill(Position{X: 0, Y: 0}, v.width, v.height, boardStyle, scrn)
+	v.composite.Draw(scrn)
+}
+
+func (v *NameEntryView) Width() int {
+	return v.width
+}
+
+func (v *NameEntryView) Height() int {
+	return v.height
+}
+
+func (v *NameEntryView) Resize(width, height int) {
+	v.width = width
+	v.height = height
+
+	top := (height - 2 - v.field.Height()) / 2
+	v.title.SetWidth(width).SetPosition(Position{X: 0, Y: top})
+	v.prompt.SetWidth(width).SetPosition(Position{X: 0, Y: top + 1})
+	v.field.SetPosition(Position{X: (width - v.field.Width()) / 2, Y: top + 2})
+}
+
+// SetTitle changes the line shown above the prompt.
+func (v *NameEntryView) SetTitle(title string) {
+	v.title.SetText(title)
+}
+
+// SetPrompt changes the line that asks for the text.
+func (v *NameEntryView) SetPrompt(prompt string) *NameEntryView {
+	v.prompt.SetText(prompt)
+	return v
+}
+
+// Field returns the field the name is typed into.
+func (v *NameEntryView) Field() *TextField {
+	return v.field
+}
+
+// NewNameEntryView returns a view for entering a name of up to maxLength characters.
+func NewNameEntryView(maxLength int) *NameEntryView {
+	ret := NameEntryView{
+		title:  NewTextBoxWithAlignment("", CenterAlignment, boardStyle).NoBorder(),
+		prompt: NewTextBoxWithAlignment(nameEntryPrompt, CenterAlignment, boardStyle).NoBorder(),
+		field:  NewTextField(maxLength, boardStyle),
+	}
+	_ = ret.Add(ret.title)
+	_ = ret.Add(ret.prompt)
+	_ = ret.Add(ret.field)
+	return &ret
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NameEntryView(t *testing.T) {
+	t.Run("shows the title and prompt above the field", func(t *testing.T) {
+		scrn := setupScreen(t, 30, 10)
+		view := NewNameEntryView(8)
+		view.SetTitle("New High Score")
+		view.Resize(30, 10)
+
+		view.Draw(scrn)
+
+		requireLine := func(y int, text string) {
+			x := (30 - len(text)) / 2
+			for i, ch := range text {
+				requireEqualContents(t, x+i, y, ch, scrn)
+			}
+		}
+		requireLine(2, "New High Score")
+		requireLine(3, nameEntryPrompt)
+		requireEqualContents(t, (30-view.Field().Width())/2, 4, tcell.RuneULCorner, scrn)
+	})
+
+	t.Run("asks for something else with another prompt", func(t *testing.T) {
+		scrn := setupScreen(t, 30, 10)
+		view := NewNameEntryView(8).SetPrompt("Seed:")
+		view.Resize(30, 10)
+
+		view.Draw(scrn)
+
+		for i, ch := range "Seed:" {
+			requireEqualContents(t, (30-len("Seed:"))/2+i, 3, ch, scrn)
+		}
+	})
+
+	t.Run("keys are typed into the field", func(t *testing.T) {
+		view := NewNameEntryView(8)
+
+		view.Handle(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone))
+
+		require.Equal(t, "x", view.Field().Text())
+	})
+}
@@ -0,0 +1,40 @@
+package ui
+
+// ReplayView shows a game board with a Timeline underneath it.
+type ReplayView struct {
+	composite
+	board    Component
+	timeline *Timeline
+}
+
+func (r *ReplayView) Width() int {
+	return max(r.board.Width(), r.timeline.Width())
+}
+
+func (r *ReplayView) Height() int {
+	return r.board.Height() + r.timeline.Height()
+}
+
+// SetBoard replaces the board shown above the timeline.
+func (r *ReplayView) SetBoard(board Component) {
+	if r.board != nil {
+		_ = r.Remove(r.board)
+	}
+	r.board = board
+	r.components = append([]Component{board}, r.components...)
+}
+
+func (r *ReplayView) Timeline() *Timeline {
+	return r.timeline
+}
+
+// NewReplayView creates a view with the timeline placed directly below the board, which is
+// expected to have its upper left corner at ul.
+func NewReplayView(ul Position, board Component) *ReplayView {
+	ret := ReplayView{
+		timeline: NewTimeline(Position{X: ul.X, Y: ul.Y + board.Height()}, board.Width()),
+	}
+	ret.SetBoard(board)
+	_ = ret.Add(ret.timeline)
+	return &ret
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReplayView(t *testing.T) {
+	board := NewGameBoardRenderer(Position{X: 0, Y: 0}, 10, 10)
+	view := NewReplayView(Position{X: 0, Y: 0}, board)
+
+	t.Run("height includes board and timeline", func(t *testing.T) {
+		require.Equal(t, board.Height()+view.Timeline().Height(), view.Height())
+	})
+
+	t.Run("width matches board", func(t *testing.T) {
+		require.Equal(t, board.Width(), view.Width())
+	})
+
+	t.Run("timeline is below the board", func(t *testing.T) {
+		require.Equal(t, Position{X: 0, Y: board.Height()}, view.Timeline().pos)
+	})
+
+	t.Run("replacing board keeps a single board", func(t *testing.T) {
+		other := NewGameBoardRenderer(Position{X: 0, Y: 0}, 10, 10)
+
+		view.SetBoard(other)
+
+		require.Len(t, view.components, 2)
+		require.Equal(t, other, view.components[0])
+	})
+}
@@ -3,11 +3,93 @@ package ui
 import "github.com/gdamore/tcell/v2"
 
 const (
-	snakeStyle = "snake"
-	foodStyle  = "food"
+	snakeStyle          = "snake"
+	snakeTwoStyle       = "snakeTwo"
+	botStyle            = "bot"
+	foodStyle           = "food"
+	goldenFoodStyle     = "goldenFood"
+	poisonFoodStyle     = "poisonFood"
+	speedFoodStyle      = "speedFood"
+	timedFoodStyle      = "timedFood"
+	wallStyle           = "wall"
+	trailStyle          = "trail"
+	slowMotionStyle     = "slowMotion"
+	ghostStyle          = "ghost"
+	shieldStyle         = "shield"
+	speedStyle          = "speed"
+	lifeLostStyle       = "lifeLost"
+	timelineCursorStyle = "timelineCursor"
+	selectedStyle       = "selected"
+	lockedStyle         = "locked"
+	invalidStyle        = "invalid"
 )
 
+const (
+	appleRune       = 'A'
+	goldenAppleRune = '$'
+	poisonAppleRune = '%'
+	speedAppleRune  = '>'
+	timedAppleRune  = '@'
+
+	trailRune = '.'
+
+	slowMotionRune = '~'
+	ghostRune      = '&'
+	shieldRune     = '+'
+	speedRune      = speedAppleRune
+)
+
+var appleRunes = map[AppleKind]rune{
+	NormalApple: appleRune,
+	GoldenApple: goldenAppleRune,
+	PoisonApple: poisonAppleRune,
+	SpeedApple:  speedAppleRune,
+	TimedApple:  timedAppleRune,
+}
+
+var appleStyles = map[AppleKind]string{
+	NormalApple: foodStyle,
+	GoldenApple: goldenFoodStyle,
+	PoisonApple: poisonFoodStyle,
+	SpeedApple:  speedFoodStyle,
+	TimedApple:  timedFoodStyle,
+}
+
+// snakeStyles are the styles of each player's snake, followed by the style shared by bots.
+var snakeStyles = []string{snakeStyle, snakeTwoStyle, botStyle}
+
+var effectRunes = map[EffectKind]rune{
+	SlowMotionEffect: slowMotionRune,
+	GhostEffect:      ghostRune,
+	ShieldEffect:     shieldRune,
+	SpeedEffect:      speedRune,
+}
+
+var effectStyles = map[EffectKind]string{
+	SlowMotionEffect: slowMotionStyle,
+	GhostEffect:      ghostStyle,
+	ShieldEffect:     shieldStyle,
+	SpeedEffect:      speedStyle,
+}
+
 var styles = map[string]tcell.Style{
-	snakeStyle: tcell.StyleDefault.Foreground(tcell.ColorGreen),
-	foodStyle:  tcell.StyleDefault.Foreground(tcell.ColorRed),
+	snakeStyle:          tcell.StyleDefault.Foreground(tcell.ColorGreen),
+	snakeTwoStyle:       tcell.StyleDefault.Foreground(tcell.ColorFuchsia),
+	botStyle:            tcell.StyleDefault.Foreground(tcell.ColorTeal),
+	foodStyle:           tcell.StyleDefault.Foreground(tcell.ColorRed),
+	goldenFoodStyle:     tcell.StyleDefault.Foreground(tcell.ColorGold),
+	poisonFoodStyle:     tcell.StyleDefault.Foreground(tcell.ColorPurple),
+	speedFoodStyle:      tcell.StyleDefault.Foreground(tcell.ColorAqua),
+	timedFoodStyle:      tcell.StyleDefault.Foreground(tcell.ColorOrange),
+	wallStyle:           tcell.StyleDefault.Foreground(tcell.ColorGray),
+	trailStyle:          tcell.StyleDefault.Foreground(tcell.ColorGreen).Dim(true),
+	slowMotionStyle:     tcell.StyleDefault.Foreground(tcell.ColorBlue),
+	ghostStyle:          tcell.StyleDefault.Foreground(tcell.ColorSilver),
+	shieldStyle:         tcell.StyleDefault.Foreground(tcell.ColorYellow),
+	speedStyle:          tcell.StyleDefault.Foreground(tcell.ColorAqua),
+	lifeLostStyle:       tcell.StyleDefault.Foreground(tcell.ColorYellow),
+	timelineCursorStyle: tcell.StyleDefault.Foreground(tcell.ColorWhite),
+	selectedStyle:       boardStyle.Reverse(true),
+	lockedStyle:         boardStyle.Foreground(tcell.ColorGray),
+	invalidStyle:        boardStyle.Foreground(tcell.ColorRed),
 }
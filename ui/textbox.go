@@ -49,7 +49,7 @@ func (t TextAlignment) Align(width int, text string) string {
 }
 
 // TextBox displays immutable text on the screen. The TextBox can be wrapped with a border and has
-// no padding.
+// no padding. Text the player can edit goes in a TextField instead.
 type TextBox struct {
 	leaf
 	upperLeft Position
@@ -112,6 +112,11 @@ func (p *TextBox) SetText(text string) *TextBox {
 	return p
 }
 
+func (p *TextBox) SetStyle(style tcell.Style) *TextBox {
+	p.style = style
+	return p
+}
+
 func (p *TextBox) NoBorder() *TextBox {
 	p.border = false
 	return p
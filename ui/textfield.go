@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TextField is a single line of editable text, drawn inside a border. The cursor is moved
+// with the arrow keys, Home and End, characters are typed in at the cursor, and Backspace
+// and Delete remove the character before or under it. Enter submits the text, as long as it
+// passes validation.
+type TextField struct {
+	leaf
+	upperLeft        Position
+	text             []rune
+	cursor           int
+	maxLength        int
+	style            tcell.Style
+	validate         func(string) error
+	err              error
+	onSubmit         func(string)
+	keyEventCallback func(*tcell.EventKey)
+}
+
+// Draw shows the text in the error style while it fails validation, with the cell under
+// the cursor reversed.
+func (f *TextField) Draw(scrn tcell.Screen) {
+	style := f.style
+	if f.err != nil {
+		style = styles[invalidStyle]
+	}
+	fill(f.upperLeft, f.Width(), f.Height(), style, scrn)
+	drawBorder(f.upperLeft, f.Width(), f.Height(), style, scrn)
+	x, y := f.upperLeft.X+1, f.upperLeft.Y+1
+	for i, ch := range f.text {
+		scrn.SetContent(x+i, y, ch, nil, style)
+	}
+	under := runeSpace
+	if f.cursor < len(f.text) {
+		under = f.text[f.cursor]
+	}
+	scrn.SetContent(x+f.cursor, y, under, nil, style.Reverse(true))
+}
+
+// Width leaves room for the border and for the cursor after the longest text.
+func (f *TextField) Width() int {
+	return f.maxLength + 3
+}
+
+func (f *TextField) Height() int {
+	return MinTextboxHeightWithBorder
+}
+
+func (f *TextField) SetPosition(pos Position) *TextField {
+	f.upperLeft = pos
+	return f
+}
+
+func (f *TextField) Text() string {
+	return string(f.text)
+}
+
+// SetText replaces the text, cutting it down to the maximum length, and moves the cursor
+// to the end.
+func (f *TextField) SetText(text string) *TextField {
+	f.text = []rune(text)
+	f.text = f.text[:min(len(f.text), f.maxLength)]
+	f.cursor = len(f.text)
+	f.check()
+	return f
+}
+
+// Cursor returns the index of the character the cursor is on, which is the length of the
+// text when it's at the end.
+func (f *TextField) Cursor() int {
+	return f.cursor
+}
+
+// SetValidator registers a function that checks the text after every change. While it
+// returns an error the field is drawn in the error style and Enter doesn't submit.
+func (f *TextField) SetValidator(validate func(string) error) *TextField {
+	f.validate = validate
+	f.check()
+	return f
+}
+
+// Err returns why the text failed validation, or nil if it's valid.
+func (f *TextField) Err() error {
+	return f.err
+}
+
+// SetOnSubmit registers the function called with the text when Enter is pressed.
+func (f *TextField) SetOnSubmit(callback func(string)) *TextField {
+	f.onSubmit = callback
+	return f
+}
+
+func (f *TextField) SetKeyEventCallback(callback func(*tcell.EventKey)) {
+	f.keyEventCallback = callback
+}
+
+func (f *TextField) handleKeyEvent(ev *tcell.EventKey) {
+	if f.keyEventCallback != nil {
+		f.keyEventCallback(ev)
+	}
+	switch ev.Key() {
+	case tcell.KeyRune:
+		f.insert(ev.Rune())
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if f.cursor > 0 {
+			f.cursor -= 1
+			f.remove()
+		}
+	case tcell.KeyDelete:
+		f.remove()
+	case tcell.KeyLeft:
+		f.cursor = max(f.cursor-1, 0)
+	case tcell.KeyRight:
+		f.cursor = min(f.cursor+1, len(f.text))
+	case tcell.KeyHome, tcell.KeyCtrlA:
+		f.cursor = 0
+	case tcell.KeyEnd, tcell.KeyCtrlE:
+		f.cursor = len(f.text)
+	case tcell.KeyEnter:
+		if f.err == nil && f.onSubmit != nil {
+			f.onSubmit(f.Text())
+		}
+	}
+}
+
+// insert types a character in at the cursor, unless the text is already at its maximum
+// length.
+func (f *TextField) insert(ch rune) {
+	if len(f.text) >= f.maxLength {
+		return
+	}
+	f.text = slices.Insert(f.text, f.cursor, ch)
+	f.cursor += 1
+	f.check()
+}
+
+// remove deletes the character under the cursor, if there is one.
+func (f *TextField) remove() {
+	if f.cursor >= len(f.text) {
+		return
+	}
+	f.text = slices.Delete(f.text, f.cursor, f.cursor+1)
+	f.check()
+}
+
+func (f *TextField) check() {
+	f.err = nil
+	if f.validate != nil {
+		f.err = f.validate(f.Text())
+	}
+}
+
+// NewTextField returns an empty field that holds up to maxLength characters.
+func NewTextField(maxLength int, style tcell.Style) *TextField {
+	return &TextField{maxLength: maxLength, style: style}
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TextField(t *testing.T) {
+	press := func(f *TextField, keys ...tcell.Key) {
+		for _, key := range keys {
+			f.handleKeyEvent(tcell.NewEventKey(key, 0, tcell.ModNone))
+		}
+	}
+	typeText := func(f *TextField, text string) {
+		for _, ch := range text {
+			f.handleKeyEvent(tcell.NewEventKey(tcell.KeyRune, ch, tcell.ModNone))
+		}
+	}
+
+	t.Run("typing adds to the text", func(t *testing.T) {
+		f := NewTextField(5, boardStyle)
+
+		typeText(f, "abc")
+
+		require.Equal(t, "abc", f.Text())
+		require.Equal(t, 3, f.Cursor())
+	})
+
+	t.Run("stops at the maximum length", func(t *testing.T) {
+		f := NewTextField(3, boardStyle)
+
+		typeText(f, "abcdef")
+
+		require.Equal(t, "abc", f.Text())
+		require.Equal(t, "xyz", f.SetText("xyzzy").Text())
+		require.Equal(t, 3, f.Cursor())
+	})
+
+	t.Run("arrows move the cursor within the text", func(t *testing.T) {
+		f := NewTextField(5, boardStyle).SetText("ab")
+
+		press(f, tcell.KeyLeft, tcell.KeyLeft, tcell.KeyLeft)
+		require.Equal(t, 0, f.Cursor())
+
+		press(f, tcell.KeyRight, tcell.KeyRight, tcell.KeyRight)
+		require.Equal(t, 2, f.Cursor())
+	})
+
+	t.Run("home and end jump to either end", func(t *testing.T) {
+		f := NewTextField(5, boardStyle).SetText("abc")
+
+		press(f, tcell.KeyHome)
+		require.Equal(t, 0, f.Cursor())
+		press(f, tcell.KeyEnd)
+		require.Equal(t, 3, f.Cursor())
+		press(f, tcell.KeyCtrlA)
+		require.Equal(t, 0, f.Cursor())
+		press(f, tcell.KeyCtrlE)
+		require.Equal(t, 3, f.Cursor())
+	})
+
+	t.Run("typing inserts at the cursor", func(t *testing.T) {
+		f := NewTextField(5, boardStyle).SetText("ac")
+
+		press(f, tcell.KeyLeft)
+		typeText(f, "b")
+
+		require.Equal(t, "abc", f.Text())
+		require.Equal(t, 2, f.Cursor())
+	})
+
+	t.Run("backspace removes the character before the cursor", func(t *testing.T) {
+		f := NewTextField(5, boardStyle).SetText("abc")
+
+		press(f, tcell.KeyLeft, tcell.KeyBackspace2)
+		require.Equal(t, "ac", f.Text())
+		require.Equal(t, 1, f.Cursor())
+
+		press(f, tcell.KeyBackspace, tcell.KeyBackspace)
+		require.Equal(t, "c", f.Text())
+		require.Equal(t, 0, f.Cursor())
+	})
+
+	t.Run("delete removes the character under the cursor", func(t *testing.T) {
+		f := NewTextField(5, boardStyle).SetText("abc")
+
+		press(f, tcell.KeyHome, tcell.KeyDelete)
+		require.Equal(t, "bc", f.Text())
+		require.Equal(t, 0, f.Cursor())
+
+		press(f, tcell.KeyEnd, tcell.KeyDelete)
+		require.Equal(t, "bc", f.Text())
+	})
+
+	t.Run("enter submits the text", func(t *testing.T) {
+		var submitted string
+		f := NewTextField(5, boardStyle).SetOnSubmit(func(text string) { submitted = text })
+		typeText(f, "hi")
+
+		press(f, tcell.KeyEnter)
+
+		require.Equal(t, "hi", submitted)
+	})
+
+	t.Run("invalid text can't be submitted", func(t *testing.T) {
+		errEmpty := errors.New("empty")
+		submitted := false
+		f := NewTextField(5, boardStyle).
+			SetValidator(func(text string) error {
+				if text == "" {
+					return errEmpty
+				}
+				return nil
+			}).
+			SetOnSubmit(func(string) { submitted = true })
+		require.ErrorIs(t, f.Err(), errEmpty)
+
+		press(f, tcell.KeyEnter)
+		require.False(t, submitted)
+
+		typeText(f, "x")
+		require.NoError(t, f.Err())
+		press(f, tcell.KeyEnter)
+		require.True(t, submitted)
+	})
+
+	t.Run("draws the text inside a border with the cursor reversed", func(t *testing.T) {
+		scrn := setupScreen(t, 10, 5)
+		f := NewTextField(4, boardStyle).SetPosition(Position{X: 1, Y: 1}).SetText("ab")
+
+		f.Draw(scrn)
+
+		require.Equal(t, 7, f.Width())
+		requireEqualContents(t, 1, 1, tcell.RuneULCorner, scrn)
+		requireEqualContents(t, 2, 2, 'a', scrn)
+		requireEqualContents(t, 3, 2, 'b', scrn)
+		requireEqualContents(t, 7, 3, tcell.RuneLRCorner, scrn)
+		_, _, style, _ := scrn.GetContent(4, 2)
+		require.Equal(t, boardStyle.Reverse(true), style)
+
+		press(f, tcell.KeyHome)
+		f.Draw(scrn)
+		_, _, style, _ = scrn.GetContent(2, 2)
+		require.Equal(t, boardStyle.Reverse(true), style)
+		_, _, style, _ = scrn.GetContent(3, 2)
+		require.Equal(t, boardStyle, style)
+	})
+
+	t.Run("draws invalid text in the error style", func(t *testing.T) {
+		scrn := setupScreen(t, 10, 5)
+		f := NewTextField(4, boardStyle).SetValidator(func(string) error { return errors.New("bad") })
+
+		f.Draw(scrn)
+
+		_, _, style, _ := scrn.GetContent(0, 0)
+		require.Equal(t, styles[invalidStyle], style)
+	})
+
+	t.Run("passes keys to its callback", func(t *testing.T) {
+		var got []tcell.Key
+		f := NewTextField(4, boardStyle)
+		f.SetKeyEventCallback(func(ev *tcell.EventKey) { got = append(got, ev.Key()) })
+
+		press(f, tcell.KeyEnter)
+
+		require.Equal(t, []tcell.Key{tcell.KeyEnter}, got)
+	})
+}
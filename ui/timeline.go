@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	timelineHeight     = 2
+	timelineCursorRune = tcell.RuneBlock
+	appleMarkerRune    = appleRune
+	lifeLostMarkerRune = snakeRune
+)
+
+const (
+	AppleMarker TimelineMarkerKind = iota
+	LifeLostMarker
+)
+
+// TimelineMarkerKind identifies what happened at a point on a Timeline.
+type TimelineMarkerKind int
+
+// TimelineMarker highlights a tick on a Timeline.
+type TimelineMarker struct {
+	Tick uint64
+	Kind TimelineMarkerKind
+}
+
+// Timeline is a progress bar for a recording. The first row shows the current position along with
+// any markers and the second row shows a status line.
+type Timeline struct {
+	leaf
+	pos     Position
+	width   int
+	total   uint64
+	current uint64
+	markers []TimelineMarker
+	status  *TextBox
+}
+
+func (t *Timeline) Draw(scrn tcell.Screen) {
+	for x := range t.width {
+		scrn.SetContent(t.pos.X+x, t.pos.Y, tcell.RuneHLine, nil, boardStyle)
+	}
+	for _, m := range t.markers {
+		r, style := appleMarkerRune, styles[foodStyle]
+		if m.Kind == LifeLostMarker {
+			r, style = lifeLostMarkerRune, styles[lifeLostStyle]
+		}
+		scrn.SetContent(t.pos.X+t.column(m.Tick), t.pos.Y, r, nil, style)
+	}
+	scrn.SetContent(t.pos.X+t.column(t.current), t.pos.Y, timelineCursorRune, nil, styles[timelineCursorStyle])
+	t.status.Draw(scrn)
+}
+
+// column converts a tick into the column it's drawn in, relative to the start of the timeline.
+func (t *Timeline) column(tick uint64) int {
+	if t.total == 0 || t.width <= 1 {
+		return 0
+	}
+	return int(min(tick, t.total) * uint64(t.width-1) / t.total)
+}
+
+func (t *Timeline) Width() int {
+	return t.width
+}
+
+func (t *Timeline) Height() int {
+	return timelineHeight
+}
+
+func (t *Timeline) SetPosition(pos Position) *Timeline {
+	t.pos = pos
+	t.status.SetPosition(Position{X: pos.X, Y: pos.Y + 1})
+	return t
+}
+
+func (t *Timeline) SetTotal(total uint64) *Timeline {
+	t.total = total
+	return t
+}
+
+func (t *Timeline) SetCurrent(current uint64) *Timeline {
+	t.current = current
+	return t
+}
+
+func (t *Timeline) SetMarkers(markers []TimelineMarker) *Timeline {
+	t.markers = markers
+	return t
+}
+
+func (t *Timeline) SetStatus(text string) *Timeline {
+	t.status.SetText(text)
+	return t
+}
+
+func (t *Timeline) StatusBox() *TextBox {
+	return t.status
+}
+
+func NewTimeline(pos Position, width int) *Timeline {
+	ret := Timeline{
+		width:  width,
+		status: NewTextBox("", boardStyle).SetWidth(width).NoBorder(),
+	}
+	ret.SetPosition(pos)
+	return &ret
+}
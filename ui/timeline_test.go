@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Timeline(t *testing.T) {
+	setupTimeline := func() *Timeline {
+		return NewTimeline(Position{X: 0, Y: 0}, 11).SetTotal(100)
+	}
+
+	t.Run("height includes status line", func(t *testing.T) {
+		require.Equal(t, 2, setupTimeline().Height())
+	})
+
+	t.Run("draws cursor at current tick", func(t *testing.T) {
+		scrn := setup(t)
+		timeline := setupTimeline().SetCurrent(50)
+
+		timeline.Draw(scrn)
+
+		requireEqualContents(t, 5, 0, timelineCursorRune, scrn)
+	})
+
+	t.Run("cursor stays on timeline past the end", func(t *testing.T) {
+		scrn := setup(t)
+		timeline := setupTimeline().SetCurrent(1000)
+
+		timeline.Draw(scrn)
+
+		requireEqualContents(t, 10, 0, timelineCursorRune, scrn)
+	})
+
+	t.Run("draws markers", func(t *testing.T) {
+		scrn := setup(t)
+		timeline := setupTimeline().SetMarkers([]TimelineMarker{
+			{Tick: 20, Kind: AppleMarker},
+			{Tick: 90, Kind: LifeLostMarker},
+		})
+
+		timeline.Draw(scrn)
+
+		requireEqualContents(t, 2, 0, appleMarkerRune, scrn)
+		requireEqualContents(t, 9, 0, lifeLostMarkerRune, scrn)
+	})
+
+	t.Run("draws status below the bar", func(t *testing.T) {
+		scrn := setup(t)
+		timeline := setupTimeline().SetStatus("Paused")
+
+		timeline.Draw(scrn)
+
+		for i, ch := range "Paused" {
+			requireEqualContents(t, i, 1, ch, scrn)
+		}
+	})
+
+	t.Run("empty timeline draws cursor at start", func(t *testing.T) {
+		scrn := setup(t)
+		timeline := NewTimeline(Position{X: 0, Y: 0}, 11)
+
+		timeline.Draw(scrn)
+
+		requireEqualContents(t, 0, 0, timelineCursorRune, scrn)
+	})
+}
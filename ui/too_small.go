@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	TooSmallViewName   = "TooSmall"
+	tooSmallText       = "Terminal too small"
+	requiredSizeFormat = "Required: %dx%d"
+	currentSizeFormat  = "Current: %dx%d"
+)
+
+// TooSmallView is shown in place of the active view while the screen is smaller than the
+// minimum size registered with the Manager. It reports both the required and current size.
+type TooSmallView struct {
+	composite
+	width, height int
+	message       *TextBox
+	required      *TextBox
+	current       *TextBox
+}
+
+// Draw clears the screen before drawing the text so nothing from the previous view is left
+// behind.
+func (v *TooSmallView) Draw(scrn tcell.Screen) {
+	fill(Position{X: 0, Y: 0}, v.width, v.height, boardStyle, scrn)
+	v.composite.Draw(scrn)
+}
+
+func (v *TooSmallView) Width() int {
+	return v.width
+}
+
+func (v *TooSmallView) Height() int {
+	return v.height
+}
+
+func (v *TooSmallView) Resize(width, height int) {
+	v.width = width
+	v.height = height
+	v.current.SetText(fmt.Sprintf(currentSizeFormat, width, height))
+
+	top := (height - 3) / 2
+	for i, box := range []*TextBox{v.message, v.required, v.current} {
+		box.SetWidth(width).SetPosition(Position{X: 0, Y: top + i})
+	}
+}
+
+func NewTooSmallView(requiredWidth, requiredHeight int) *TooSmallView {
+	newLine := func(text string) *TextBox {
+		return NewTextBoxWithAlignment(text, CenterAlignment, boardStyle).NoBorder()
+	}
+	ret := TooSmallView{
+		message:  newLine(tooSmallText),
+		required: newLine(fmt.Sprintf(requiredSizeFormat, requiredWidth, requiredHeight)),
+		current:  newLine(""),
+	}
+	_ = ret.Add(ret.message)
+	_ = ret.Add(ret.required)
+	_ = ret.Add(ret.current)
+	ret.Resize(0, 0)
+	return &ret
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TooSmallView(t *testing.T) {
+	t.Run("shows required and current size", func(t *testing.T) {
+		scrn := setupScreen(t, 30, 5)
+		view := NewTooSmallView(40, 20)
+		view.Resize(30, 5)
+
+		view.Draw(scrn)
+
+		requireLine := func(y int, text string) {
+			x := (30 - len(text)) / 2
+			for i, ch := range text {
+				requireEqualContents(t, x+i, y, ch, scrn)
+			}
+		}
+		requireLine(1, tooSmallText)
+		requireLine(2, "Required: 40x20")
+		requireLine(3, "Current: 30x5")
+	})
+
+	t.Run("size follows the screen", func(t *testing.T) {
+		view := NewTooSmallView(40, 20)
+
+		view.Resize(12, 7)
+
+		require.Equal(t, 12, view.Width())
+		require.Equal(t, 7, view.Height())
+	})
+
+	t.Run("doesn't panic on an empty screen", func(t *testing.T) {
+		scrn := setupScreen(t, 0, 0)
+		view := NewTooSmallView(40, 20)
+
+		require.NotPanics(t, func() {
+			view.Resize(0, 0)
+			view.Draw(scrn)
+		})
+	})
+}
+
+func Test_ComponentsDoNotPanicOnSmallScreens(t *testing.T) {
+	for _, size := range [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 5}, {-1, -1}} {
+		width, height := size[0], size[1]
+		scrn := setupScreen(t, max(width, 0), max(height, 0))
+
+		require.NotPanics(t, func() {
+			board := NewGameBoardRenderer(Position{X: 0, Y: 0}, width, height)
+			board.Draw(scrn)
+			board.SetSize(width, height)
+			board.Draw(scrn)
+		}, "game board %dx%d", width, height)
+
+		require.NotPanics(t, func() {
+			menu := NewMenu(Position{X: 0, Y: 0}, width, height, "Menu")
+			menu.AddEntry("Entry")
+			menu.Draw(scrn)
+		}, "menu %dx%d", width, height)
+
+		require.NotPanics(t, func() {
+			ShowMessage(NewGameBoardRenderer(Position{X: 0, Y: 0}, width, height), "message", scrn)
+		}, "message %dx%d", width, height)
+	}
+}
@@ -5,12 +5,10 @@ import "github.com/gdamore/tcell/v2"
 const runeSpace rune = ' '
 
 // drawBorder renders a rectangular border onto the screen using the given position, dimensions, and style.
+// Nothing is drawn when either dimension is less than one.
 func drawBorder(start Position, width int, height int, style tcell.Style, scrn tcell.Screen) {
-	if width <= 0 {
-		panic("width must be greater than zero")
-	}
-	if height <= 0 {
-		panic("height must be greater than zero")
+	if width <= 0 || height <= 0 {
+		return
 	}
 
 	for x := start.X; x < start.X+width; x++ {
@@ -28,14 +26,8 @@ func drawBorder(start Position, width int, height int, style tcell.Style, scrn t
 }
 
 // fill renders a rectangular area onto the screen using the given position, dimensions, and style.
+// Nothing is drawn when either dimension is less than one.
 func fill(start Position, width int, height int, style tcell.Style, scrn tcell.Screen) {
-	if width <= 0 {
-		panic("width must be greater than zero")
-	}
-	if height <= 0 {
-		panic("height must be greater than zero")
-	}
-
 	for y := start.Y; y < start.Y+height; y++ {
 		for x := start.X; x < start.X+width; x++ {
 			scrn.SetContent(x, y, runeSpace, nil, style)
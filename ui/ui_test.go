@@ -22,7 +22,7 @@ func Test_DrawBorder(t *testing.T) {
 				t.Errorf("expected panic, but none occurred")
 			}
 		}()
-		drawBorder(Position{}, 0, 0, tcell.Style{}, nil)
+		drawBorder(Position{}, 1, 1, tcell.Style{}, nil)
 	})
 
 	t.Run("draws border", func(t *testing.T) {
@@ -36,26 +36,22 @@ func Test_DrawBorder(t *testing.T) {
 		assertEqualContents(t, Position{X: 4, Y: 4}, tcell.RuneLRCorner, scrn)
 	})
 
-	t.Run("panics if width is less than one", func(t *testing.T) {
+	t.Run("draws nothing if width is less than one", func(t *testing.T) {
 		scrn := setup(t)
+		scrn.SetContent(1, 1, 'x', nil, tcell.StyleDefault)
 
-		defer func() {
-			if r := recover(); r == nil {
-				t.Errorf("expected panic, but none occurred")
-			}
-		}()
-		drawBorder(Position{}, 0, 1, tcell.StyleDefault, scrn)
+		drawBorder(Position{X: 1, Y: 1}, 0, 1, tcell.StyleDefault, scrn)
+
+		assertEqualContents(t, Position{X: 1, Y: 1}, 'x', scrn)
 	})
 
-	t.Run("panics if height is less than one", func(t *testing.T) {
+	t.Run("draws nothing if height is less than one", func(t *testing.T) {
 		scrn := setup(t)
+		scrn.SetContent(1, 1, 'x', nil, tcell.StyleDefault)
 
-		defer func() {
-			if r := recover(); r == nil {
-				t.Errorf("expected panic, but none occurred")
-			}
-		}()
-		drawBorder(Position{}, 1, 0, tcell.StyleDefault, scrn)
+		drawBorder(Position{X: 1, Y: 1}, 1, 0, tcell.StyleDefault, scrn)
+
+		assertEqualContents(t, Position{X: 1, Y: 1}, 'x', scrn)
 	})
 }
 
@@ -66,29 +62,25 @@ func Test_Fill(t *testing.T) {
 				t.Errorf("expected panic, but none occurred")
 			}
 		}()
-		drawBorder(Position{}, 0, 0, tcell.Style{}, nil)
+		fill(Position{}, 1, 1, tcell.Style{}, nil)
 	})
 
-	t.Run("panics if width is less than one", func(t *testing.T) {
+	t.Run("draws nothing if width is less than one", func(t *testing.T) {
 		scrn := setup(t)
+		scrn.SetContent(1, 1, 'x', nil, tcell.StyleDefault)
 
-		defer func() {
-			if r := recover(); r == nil {
-				t.Errorf("expected panic, but none occurred")
-			}
-		}()
-		fill(Position{}, 0, 1, tcell.StyleDefault, scrn)
+		fill(Position{X: 1, Y: 1}, 0, 1, tcell.StyleDefault, scrn)
+
+		assertEqualContents(t, Position{X: 1, Y: 1}, 'x', scrn)
 	})
 
-	t.Run("panics if height is less than one", func(t *testing.T) {
+	t.Run("draws nothing if height is less than one", func(t *testing.T) {
 		scrn := setup(t)
+		scrn.SetContent(1, 1, 'x', nil, tcell.StyleDefault)
 
-		defer func() {
-			if r := recover(); r == nil {
-				t.Errorf("expected panic, but none occurred")
-			}
-		}()
-		fill(Position{}, 1, 0, tcell.StyleDefault, scrn)
+		fill(Position{X: 1, Y: 1}, 1, 0, tcell.StyleDefault, scrn)
+
+		assertEqualContents(t, Position{X: 1, Y: 1}, 'x', scrn)
 	})
 
 	t.Run("fills screen in with blanks", func(t *testing.T) {
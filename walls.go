@@ -0,0 +1,53 @@
+package main
+
+import "fmt"
+
+// WallMode controls what happens when the snake runs into the edge of the board.
+type WallMode int
+
+const (
+	// SolidWalls stop the snake until it turns away from the wall.
+	SolidWalls WallMode = iota
+	// DeadlyWalls cost a life, like running into the snake's own body.
+	DeadlyWalls
+	// WrapWalls move the snake to the opposite edge of the board.
+	WrapWalls
+	// BounceWalls turn the snake along the wall, clockwise unless that's into another wall.
+	BounceWalls
+)
+
+var wallModes = []WallMode{SolidWalls, DeadlyWalls, WrapWalls, BounceWalls}
+
+func (w WallMode) String() string {
+	switch w {
+	case SolidWalls:
+		return "solid"
+	case DeadlyWalls:
+		return "deadly"
+	case WrapWalls:
+		return "wrap"
+	case BounceWalls:
+		return "bounce"
+	default:
+		return fmt.Sprintf("unrecognized wall mode: %d", int(w))
+	}
+}
+
+func (w WallMode) MarshalText() ([]byte, error) {
+	switch w {
+	case SolidWalls, DeadlyWalls, WrapWalls, BounceWalls:
+		return []byte(w.String()), nil
+	default:
+		return nil, fmt.Errorf("unrecognized wall mode: %d", int(w))
+	}
+}
+
+func (w *WallMode) UnmarshalText(text []byte) error {
+	for _, mode := range wallModes {
+		if mode.String() == string(text) {
+			*w = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized wall mode: %q", text)
+}